@@ -28,6 +28,8 @@ type options struct {
 	ConfigFilePath string
 	Config         Config
 	GoferNoRPC     bool
+	DryRun         bool
+	DryRunOutput   string
 }
 
 func NewRootCommand(opts *options) *cobra.Command {
@@ -25,7 +25,7 @@ import (
 )
 
 func NewRunCmd(opts *options) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "run",
 		Args:    cobra.ExactArgs(0),
 		Aliases: []string{"agent"},
@@ -48,4 +48,19 @@ func NewRunCmd(opts *options) *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(
+		&opts.DryRun,
+		"dry-run",
+		false,
+		"write signed prices as NDJSON instead of sending them to the network",
+	)
+	cmd.Flags().StringVar(
+		&opts.DryRunOutput,
+		"dry-run.output",
+		"-",
+		"file to which signed prices are appended in the dry-run mode, \"-\" for stdout",
+	)
+
+	return cmd
 }
@@ -0,0 +1,58 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/makerdao/oracle-suite/internal/config"
+)
+
+func NewStatusCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Args:  cobra.ExactArgs(0),
+		Short: "Show the last signed and broadcast price for every pair",
+		Long:  `Shows the last signed and broadcast price for every pair, as stored in the state file.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			err := config.ParseFile(&opts.Config, opts.ConfigFilePath)
+			if err != nil {
+				return fmt.Errorf("failed to parse configuration file: %w", err)
+			}
+			state := opts.Config.Ghost.ConfigureState()
+			if state == nil {
+				return errors.New("the state file is not configured")
+			}
+			s, err := state.Load()
+			if err != nil {
+				return err
+			}
+
+			bts, err := json.Marshal(s)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%s\n", string(bts))
+
+			return nil
+		},
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/sirupsen/logrus"
 
@@ -27,12 +29,16 @@ import (
 	feedsConfig "github.com/makerdao/oracle-suite/internal/config/feeds"
 	ghostConfig "github.com/makerdao/oracle-suite/internal/config/ghost"
 	goferConfig "github.com/makerdao/oracle-suite/internal/config/gofer"
+	metricsConfig "github.com/makerdao/oracle-suite/internal/config/metrics"
 	transportConfig "github.com/makerdao/oracle-suite/internal/config/transport"
+	"github.com/makerdao/oracle-suite/internal/metrics"
+	"github.com/makerdao/oracle-suite/pkg/datastore"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/ghost"
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 	logLogrus "github.com/makerdao/oracle-suite/pkg/log/logrus"
 	"github.com/makerdao/oracle-suite/pkg/transport"
+	"github.com/makerdao/oracle-suite/pkg/transport/writer"
 
 	"github.com/makerdao/oracle-suite/pkg/log"
 )
@@ -42,62 +48,95 @@ type Config struct {
 	Ethereum  ethereumConfig.Ethereum   `json:"ethereum"`
 	Transport transportConfig.Transport `json:"transport"`
 	Ghost     ghostConfig.Ghost         `json:"ghost"`
+	Metrics   metricsConfig.Metrics     `json:"metrics"`
 	Feeds     feedsConfig.Feeds         `json:"feeds"`
 }
 
 type Dependencies struct {
 	Context context.Context
 	Logger  log.Logger
+	// DryRunOutput, if set, enables the dry-run mode. In this mode, signed
+	// prices are written to the DryRunOutput as separate price messages
+	// instead of being sent to the network, even if bundles are enabled,
+	// and the state file is neither read nor written.
+	DryRunOutput io.Writer
 }
 
-func (c *Config) Configure(d Dependencies, noGoferRPC bool) (transport.Transport, gofer.Gofer, *ghost.Ghost, error) {
+func (c *Config) Configure(d Dependencies, noGoferRPC bool) (transport.Transport, gofer.Gofer, datastore.Datastore, *ghost.Ghost, error) {
 	sig, err := c.Ethereum.ConfigureSigner()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	cli, err := c.Ethereum.ConfigureEthereumClient(nil) // signer may be empty here
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	gof, err := c.Gofer.ConfigureGofer(d.Context, cli, d.Logger, noGoferRPC)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	if sig.Address() == ethereum.EmptyAddress {
-		return nil, nil, nil, errors.New("ethereum account must be configured")
+		return nil, nil, nil, nil, errors.New("ethereum account must be configured")
 	}
 	fed, err := c.Feeds.Addresses()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
-	tra, err := c.Transport.Configure(transportConfig.Dependencies{
-		Context: d.Context,
-		Signer:  sig,
-		Feeds:   fed,
-		Logger:  d.Logger,
+	var tra transport.Transport
+	if d.DryRunOutput != nil {
+		tra, err = writer.New(d.Context, d.DryRunOutput)
+	} else {
+		tra, err = c.Transport.Configure(transportConfig.Dependencies{
+			Context: d.Context,
+			Signer:  sig,
+			Feeds:   fed,
+			Logger:  d.Logger,
+		})
+	}
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	dat, err := c.Ghost.ConfigureDatastore(ghostConfig.DatastoreDependencies{
+		Context:   d.Context,
+		Signer:    sig,
+		Transport: tra,
+		Feeds:     fed,
+		Logger:    d.Logger,
 	})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+	ghoCfg := c.Ghost
+	if d.DryRunOutput != nil {
+		// Prices written in the dry-run mode must be replayable using
+		// the "spire push price" command, which does not support bundles.
+		// The state is not persisted, because prices are never sent.
+		ghoCfg.Bundle = false
+		ghoCfg.StateFile = ""
 	}
-	gho, err := c.Ghost.Configure(ghostConfig.Dependencies{
+	gho, err := ghoCfg.Configure(ghostConfig.Dependencies{
 		Context:   d.Context,
 		Gofer:     gof,
 		Signer:    sig,
 		Transport: tra,
+		Datastore: dat,
 		Logger:    d.Logger,
 	})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
-	return tra, gof, gho, nil
+	return tra, gof, dat, gho, nil
 }
 
 type Services struct {
 	ctxCancel context.CancelFunc
+	output    io.Closer
 	Transport transport.Transport
 	Gofer     gofer.Gofer
+	Datastore datastore.Datastore
 	Ghost     *ghost.Ghost
+	Metrics   *metrics.Server
 }
 
 func PrepareServices(ctx context.Context, opts *options) (*Services, error) {
@@ -125,20 +164,42 @@ func PrepareServices(ctx context.Context, opts *options) (*Services, error) {
 	lr.SetFormatter(opts.LogFormat.Formatter())
 	logger := logLogrus.New(lr)
 
+	// Dry-run output:
+	var out io.WriteCloser
+	if opts.DryRun {
+		out, err = openDryRunOutput(opts.DryRunOutput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open dry-run output: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				out.Close()
+			}
+		}()
+	}
+
 	// Services:
-	tra, gof, gho, err := opts.Config.Configure(Dependencies{
-		Context: ctx,
-		Logger:  logger,
+	tra, gof, dat, gho, err := opts.Config.Configure(Dependencies{
+		Context:      ctx,
+		Logger:       logger,
+		DryRunOutput: out,
 	}, opts.GoferNoRPC)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load Ghost configuration: %w", err)
 	}
+	met, err := opts.Config.Metrics.Configure(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics configuration: %w", err)
+	}
 
 	return &Services{
 		ctxCancel: ctxCancel,
+		output:    out,
 		Transport: tra,
 		Gofer:     gof,
+		Datastore: dat,
 		Ghost:     gho,
+		Metrics:   met,
 	}, nil
 }
 
@@ -152,17 +213,51 @@ func (s *Services) Start() error {
 	if err = s.Transport.Start(); err != nil {
 		return err
 	}
+	if s.Datastore != nil {
+		if err = s.Datastore.Start(); err != nil {
+			return err
+		}
+	}
 	if err = s.Ghost.Start(); err != nil {
 		return err
 	}
+	if s.Metrics != nil {
+		if err = s.Metrics.Start(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (s *Services) CancelAndWait() {
 	s.ctxCancel()
 	s.Transport.Wait()
+	if s.Datastore != nil {
+		s.Datastore.Wait()
+	}
 	s.Ghost.Wait()
+	if s.Metrics != nil {
+		s.Metrics.Wait()
+	}
 	if g, ok := s.Gofer.(gofer.StartableGofer); ok {
 		g.Wait()
 	}
+	if s.output != nil {
+		s.output.Close()
+	}
+}
+
+// openDryRunOutput opens a file to which signed prices are written in
+// the dry-run mode. If the path is "-", the standard output is used.
+func openDryRunOutput(path string) (io.WriteCloser, error) {
+	if path == "-" || path == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
 }
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
@@ -28,8 +28,8 @@ func NewAgentCmd(opts *options) *cobra.Command {
 	return &cobra.Command{
 		Use:   "agent",
 		Args:  cobra.NoArgs,
-		Short: "Start an RPC server",
-		Long:  `Start an RPC server.`,
+		Short: "Start an RPC server and optionally an HTTP server",
+		Long:  `Start an RPC server and optionally an HTTP server.`,
 		RunE: func(_ *cobra.Command, args []string) error {
 			srv, err := PrepareGoferAgentService(context.Background(), opts)
 			if err != nil {
@@ -0,0 +1,96 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/gofer/rpc"
+)
+
+func NewHistoryCmd(opts *options) *cobra.Command {
+	var since time.Duration
+	cmd := &cobra.Command{
+		Use:   "history [PAIR...]",
+		Args:  cobra.MinimumNArgs(0),
+		Short: "Return price history for given PAIRs",
+		Long: `Return prices fetched from origins used to calculate prices for given PAIRs.
+
+The history is kept only by the agent, so the agent must be running and
+the history must be enabled in the configuration file.`,
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			srv, err := PrepareGoferClientServices(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err != nil {
+					exitCode = 1
+					_ = srv.Marshaller.Write(os.Stderr, err)
+				}
+				_ = srv.Marshaller.Flush()
+				// Set err to nil because error was already handled by marshaller.
+				err = nil
+			}()
+			if err = srv.Start(); err != nil {
+				return err
+			}
+			defer srv.CancelAndWait()
+
+			// The history is kept only in the agent's memory:
+			hg, ok := srv.Gofer.(*rpc.Gofer)
+			if !ok {
+				return errors.New("price history is available only when the agent is used")
+			}
+
+			pairs, err := gofer.NewPairs(args...)
+			if err != nil {
+				return err
+			}
+
+			history, err := hg.History(time.Now().Add(-since), pairs...)
+			if err != nil {
+				return err
+			}
+
+			var ps []gofer.Pair
+			for p := range history {
+				ps = append(ps, p)
+			}
+			sort.Slice(ps, func(i, j int) bool {
+				return ps[i].String() < ps[j].String()
+			})
+			for _, p := range ps {
+				for _, s := range history[p] {
+					if mErr := srv.Marshaller.Write(os.Stdout, s); mErr != nil {
+						_ = srv.Marshaller.Write(os.Stderr, mErr)
+					}
+				}
+			}
+
+			return
+		},
+	}
+	cmd.Flags().DurationVar(&since, "since", time.Hour, "return prices fetched within the given duration")
+	return cmd
+}
@@ -24,8 +24,11 @@ import (
 	"github.com/makerdao/oracle-suite/internal/config"
 	ethereumConfig "github.com/makerdao/oracle-suite/internal/config/ethereum"
 	goferConfig "github.com/makerdao/oracle-suite/internal/config/gofer"
+	metricsConfig "github.com/makerdao/oracle-suite/internal/config/metrics"
 	"github.com/makerdao/oracle-suite/internal/gofer/marshal"
+	"github.com/makerdao/oracle-suite/internal/metrics"
 	pkgGofer "github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/gofer/httpapi"
 	"github.com/makerdao/oracle-suite/pkg/gofer/rpc"
 	"github.com/makerdao/oracle-suite/pkg/log"
 	logLogrus "github.com/makerdao/oracle-suite/pkg/log/logrus"
@@ -34,6 +37,7 @@ import (
 type Config struct {
 	Ethereum ethereumConfig.Ethereum `json:"ethereum"`
 	Gofer    goferConfig.Gofer       `json:"gofer"`
+	Metrics  metricsConfig.Metrics   `json:"metrics"`
 }
 
 func (c *Config) Configure(ctx context.Context, logger log.Logger, noRPC bool) (pkgGofer.Gofer, error) {
@@ -44,12 +48,24 @@ func (c *Config) Configure(ctx context.Context, logger log.Logger, noRPC bool) (
 	return c.Gofer.ConfigureGofer(ctx, cli, logger, noRPC)
 }
 
-func (c *Config) ConfigureRPCAgent(ctx context.Context, logger log.Logger) (*rpc.Agent, error) {
+func (c *Config) ConfigureAgents(ctx context.Context, logger log.Logger) (*rpc.Agent, *httpapi.Server, error) {
 	cli, err := c.Ethereum.ConfigureEthereumClient(nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	gof, err := c.Gofer.ConfigureAsyncGofer(ctx, cli, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	age, err := c.Gofer.ConfigureRPCAgent(ctx, gof, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	srv, err := c.Gofer.ConfigureHTTPServer(ctx, gof, logger)
+	if err != nil {
+		return nil, nil, err
 	}
-	return c.Gofer.ConfigureRPCAgent(ctx, cli, logger)
+	return age, srv, nil
 }
 
 type GoferClientServices struct {
@@ -117,6 +133,8 @@ func (s *GoferClientServices) CancelAndWait() {
 type GoferAgentService struct {
 	ctxCancel context.CancelFunc
 	Agent     *rpc.Agent
+	HTTP      *httpapi.Server
+	Metrics   *metrics.Server
 }
 
 func PrepareGoferAgentService(ctx context.Context, opts *options) (*GoferAgentService, error) {
@@ -145,22 +163,45 @@ func PrepareGoferAgentService(ctx context.Context, opts *options) (*GoferAgentSe
 	logger := logLogrus.New(lr)
 
 	// Services:
-	age, err := opts.Config.ConfigureRPCAgent(ctx, logger)
+	age, srv, err := opts.Config.ConfigureAgents(ctx, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load Gofer configuration: %w", err)
 	}
+	met, err := opts.Config.Metrics.Configure(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics configuration: %w", err)
+	}
 
 	return &GoferAgentService{
 		ctxCancel: ctxCancel,
 		Agent:     age,
+		HTTP:      srv,
+		Metrics:   met,
 	}, nil
 }
 
 func (s *GoferAgentService) Start() error {
-	return s.Agent.Start()
+	if err := s.Agent.Start(); err != nil {
+		return err
+	}
+	if s.HTTP != nil {
+		if err := s.HTTP.Start(); err != nil {
+			return err
+		}
+	}
+	if s.Metrics != nil {
+		return s.Metrics.Start()
+	}
+	return nil
 }
 
 func (s *GoferAgentService) CancelAndWait() {
 	s.ctxCancel()
 	s.Agent.Wait()
+	if s.HTTP != nil {
+		s.HTTP.Wait()
+	}
+	if s.Metrics != nil {
+		s.Metrics.Wait()
+	}
 }
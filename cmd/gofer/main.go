@@ -36,6 +36,7 @@ func main() {
 	rootCmd.AddCommand(
 		NewPairsCmd(&opts),
 		NewPricesCmd(&opts),
+		NewHistoryCmd(&opts),
 		NewAgentCmd(&opts),
 	)
 
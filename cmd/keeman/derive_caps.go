@@ -22,20 +22,31 @@ import (
 	"log"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+
+	"github.com/makerdao/oracle-suite/pkg/starkex"
 )
 
 type caps struct {
-	Shs  []byte
-	Sign []byte
+	Shs   []byte
+	Sign  []byte
+	Stark *starkex.PrivateKey
 }
 
 func (c caps) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		Shs  string `json:"shs"`
-		Sign string `json:"sign"`
-	}{Shs: base64.URLEncoding.EncodeToString(c.Shs), Sign: base64.URLEncoding.EncodeToString(c.Sign)})
+		Shs     string `json:"shs"`
+		Sign    string `json:"sign"`
+		Stark   string `json:"stark"`
+		StarkPK string `json:"starkPK"`
+	}{
+		Shs:     base64.URLEncoding.EncodeToString(c.Shs),
+		Sign:    base64.URLEncoding.EncodeToString(c.Sign),
+		Stark:   hexutil.Encode(c.Stark.Bytes()),
+		StarkPK: hexutil.EncodeBig(c.Stark.PublicKey()),
+	})
 }
 
 func genCaps(wallet *hdwallet.Wallet, path accounts.DerivationPath) (*caps, error) {
@@ -58,7 +69,18 @@ func genCaps(wallet *hdwallet.Wallet, path accounts.DerivationPath) (*caps, erro
 		return nil, err
 	}
 
-	return &caps{Shs: crypto.FromECDSA(a), Sign: crypto.FromECDSA(b)}, nil
+	dpc := iter()
+	log.Printf("caps.stark path: %s", dpc)
+	c, err := deriveKey(wallet, dpc)
+	if err != nil {
+		return nil, err
+	}
+	s, err := starkex.DerivePrivateKey(crypto.FromECDSA(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return &caps{Shs: crypto.FromECDSA(a), Sign: crypto.FromECDSA(b), Stark: s}, nil
 }
 
 func capsIterator(base accounts.DerivationPath) (func() accounts.DerivationPath, error) {
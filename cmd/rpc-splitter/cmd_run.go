@@ -76,17 +76,17 @@ func NewRunCmd(opts *options) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			defer func() {
-				err := srv.Wait()
-				if err != nil {
-					log.WithError(err).Error("Error while closing HTTP server")
-				}
-			}()
 
 			c := make(chan os.Signal, 1)
 			signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 			<-c
 
+			// The server is closed only after the context is cancelled:
+			ctxCancel()
+			if err = srv.Wait(); err != nil {
+				log.WithError(err).Error("Error while closing HTTP server")
+			}
+
 			return nil
 		},
 	}
@@ -0,0 +1,159 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/makerdao/oracle-suite/internal/config"
+	"github.com/makerdao/oracle-suite/pkg/oracle"
+	"github.com/makerdao/oracle-suite/pkg/spectre"
+)
+
+func NewStatusCmd(opts *options) *cobra.Command {
+	var addr string
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "status [PAIR...]",
+		Short: "Show the status of relayed pairs",
+		Long: `Shows the status of relayed pairs reported by the running Spectre instance: ` +
+			`the Oracle state, candidate prices, spread, expiration, the last poke ` +
+			`transaction and the reason why the last poke was not sent.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if addr == "" {
+				err := config.ParseFile(&opts.Config, opts.ConfigFilePath)
+				if err != nil {
+					return fmt.Errorf("failed to parse configuration file: %w", err)
+				}
+				addr = opts.Config.Spectre.Status.Address
+			}
+			if addr == "" {
+				return errors.New("the status server is not configured")
+			}
+			statuses, raw, err := fetchStatus(addr, args)
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				fmt.Printf("%s\n", string(raw))
+				return nil
+			}
+			printStatus(os.Stdout, statuses, time.Now())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(
+		&addr,
+		"addr",
+		"",
+		"address of the status server, if empty, the address from the config file is used",
+	)
+	cmd.Flags().BoolVar(
+		&asJSON,
+		"json",
+		false,
+		"print the status in the JSON format",
+	)
+	return cmd
+}
+
+func fetchStatus(addr string, pairs []string) ([]spectre.PairStatus, []byte, error) {
+	u := url.URL{Scheme: "http", Host: addr, Path: "/status"}
+	if len(pairs) > 0 {
+		u.RawQuery = url.Values{"pair": []string{strings.Join(pairs, ",")}}.Encode()
+	}
+	res, err := http.Get(u.String()) //nolint:gosec,noctx
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(raw, &e) == nil && e.Error != "" {
+			return nil, nil, errors.New(e.Error)
+		}
+		return nil, nil, fmt.Errorf("unexpected response: %s", res.Status)
+	}
+	var statuses []spectre.PairStatus
+	if err := json.Unmarshal(raw, &statuses); err != nil {
+		return nil, nil, err
+	}
+	return statuses, raw, nil
+}
+
+func printStatus(w io.Writer, statuses []spectre.PairStatus, now time.Time) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+	for _, st := range statuses {
+		fmt.Fprintf(tw, "%s (%s)\n", st.Name, st.AssetPair)
+		fmt.Fprintf(tw, "  val:\t%s\n", formatPrice(st.Val))
+		fmt.Fprintf(tw, "  age:\t%s\n", formatTime(st.Age, now))
+		fmt.Fprintf(tw, "  bar:\t%d\n", st.Bar)
+		fmt.Fprintf(tw, "  spread:\t%.4f%%\n", st.Spread)
+		if st.Expiration.IsZero() {
+			fmt.Fprintf(tw, "  expires in:\t-\n")
+		} else {
+			fmt.Fprintf(tw, "  expires in:\t%s\n", st.Expiration.Sub(now).Round(time.Second))
+		}
+		if st.LastPoke != nil {
+			fmt.Fprintf(tw, "  last poke:\t%s (%s, sent %s)\n",
+				st.LastPoke.Tx.String(), st.LastPoke.Status, formatTime(st.LastPoke.SentAt, now))
+		} else {
+			fmt.Fprintf(tw, "  last poke:\t-\n")
+		}
+		if st.Reason != "" {
+			fmt.Fprintf(tw, "  not poked:\t%s\n", st.Reason)
+		}
+		fmt.Fprintf(tw, "  updated:\t%s\n", formatTime(st.UpdatedAt, now))
+		fmt.Fprintf(tw, "  prices:\t%d\n", len(st.Prices))
+		for _, p := range st.Prices {
+			fmt.Fprintf(tw, "    %s\t%s\t%s\n", p.Feeder.String(), formatPrice(p.Val), formatTime(p.Age, now))
+		}
+		fmt.Fprintln(tw)
+	}
+}
+
+func formatPrice(val *big.Int) string {
+	if val == nil {
+		return "-"
+	}
+	return new(big.Float).Quo(new(big.Float).SetInt(val), new(big.Float).SetFloat64(oracle.PriceMultiplier)).Text('f', 8)
+}
+
+func formatTime(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.UTC().Format(time.RFC3339), now.Sub(t).Round(time.Second))
+}
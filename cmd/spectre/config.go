@@ -24,12 +24,16 @@ import (
 	"github.com/makerdao/oracle-suite/internal/config"
 	ethereumConfig "github.com/makerdao/oracle-suite/internal/config/ethereum"
 	feedsConfig "github.com/makerdao/oracle-suite/internal/config/feeds"
+	metricsConfig "github.com/makerdao/oracle-suite/internal/config/metrics"
 	spectreConfig "github.com/makerdao/oracle-suite/internal/config/spectre"
 	transportConfig "github.com/makerdao/oracle-suite/internal/config/transport"
+	"github.com/makerdao/oracle-suite/internal/metrics"
 	"github.com/makerdao/oracle-suite/pkg/datastore"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/txmanager"
 	"github.com/makerdao/oracle-suite/pkg/log"
 	logLogrus "github.com/makerdao/oracle-suite/pkg/log/logrus"
 	"github.com/makerdao/oracle-suite/pkg/spectre"
+	"github.com/makerdao/oracle-suite/pkg/spectre/httpapi"
 	"github.com/makerdao/oracle-suite/pkg/transport"
 )
 
@@ -37,6 +41,7 @@ type Config struct {
 	Transport transportConfig.Transport `json:"transport"`
 	Ethereum  ethereumConfig.Ethereum   `json:"ethereum"`
 	Spectre   spectreConfig.Spectre     `json:"spectre"`
+	Metrics   metricsConfig.Metrics     `json:"metrics"`
 	Feeds     feedsConfig.Feeds         `json:"feeds"`
 }
 
@@ -45,27 +50,40 @@ type Dependencies struct {
 	Logger  log.Logger
 }
 
-func (c *Config) Configure(d Dependencies) (transport.Transport, datastore.Datastore, *spectre.Spectre, error) {
+func (c *Config) Configure(d Dependencies) (
+	transport.Transport,
+	datastore.Datastore,
+	[]*txmanager.TxManager,
+	*spectre.Coordinator,
+	*spectre.Spectre,
+	error,
+) {
+
 	sig, err := c.Ethereum.ConfigureSigner()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 	cli, err := c.Ethereum.ConfigureEthereumClient(sig)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 	fed, err := c.Feeds.Addresses()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
+	}
+	rel, err := c.Spectre.Coordination.Addresses()
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
 	}
 	tra, err := c.Transport.Configure(transportConfig.Dependencies{
-		Context: d.Context,
-		Signer:  sig,
-		Feeds:   fed,
-		Logger:  d.Logger,
+		Context:  d.Context,
+		Signer:   sig,
+		Feeds:    fed,
+		Logger:   d.Logger,
+		Relayers: rel,
 	})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 	dat, err := c.Spectre.ConfigureDatastore(spectreConfig.DatastoreDependencies{
 		Context:   d.Context,
@@ -75,26 +93,63 @@ func (c *Config) Configure(d Dependencies) (transport.Transport, datastore.Datas
 		Logger:    d.Logger,
 	})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
+	}
+	txm, err := c.Spectre.ConfigureTxManager(spectreConfig.TxManagerDependencies{
+		Context:        d.Context,
+		Signer:         sig,
+		EthereumClient: cli,
+		MaxFee:         c.Ethereum.Fees.Ceiling(),
+		Logger:         d.Logger,
+	})
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	chs, err := c.Spectre.ConfigureChains(spectreConfig.ChainsDependencies{
+		Context: d.Context,
+		Logger:  d.Logger,
+	})
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	txms := []*txmanager.TxManager{txm}
+	for _, ch := range chs {
+		txms = append(txms, ch.TxManager)
+	}
+	crd, err := c.Spectre.ConfigureCoordinator(spectreConfig.CoordinatorDependencies{
+		Context:   d.Context,
+		Signer:    sig,
+		Transport: tra,
+		Logger:    d.Logger,
+	})
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
 	}
 	spe, err := c.Spectre.ConfigureSpectre(spectreConfig.Dependencies{
 		Context:        d.Context,
 		Signer:         sig,
 		Datastore:      dat,
 		EthereumClient: cli,
+		TxManager:      txm,
+		Coordinator:    crd,
+		Chains:         chs,
 		Logger:         d.Logger,
 	})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
-	return tra, dat, spe, nil
+	return tra, dat, txms, crd, spe, nil
 }
 
 type Services struct {
-	ctxCancel context.CancelFunc
-	Transport transport.Transport
-	Datastore datastore.Datastore
-	Spectre   *spectre.Spectre
+	ctxCancel   context.CancelFunc
+	Transport   transport.Transport
+	Datastore   datastore.Datastore
+	TxManagers  []*txmanager.TxManager
+	Coordinator *spectre.Coordinator
+	Spectre     *spectre.Spectre
+	Status      *httpapi.Server
+	Metrics     *metrics.Server
 }
 
 func PrepareServices(ctx context.Context, opts *options) (*Services, error) {
@@ -123,19 +178,35 @@ func PrepareServices(ctx context.Context, opts *options) (*Services, error) {
 	logger := logLogrus.New(lr)
 
 	// Services:
-	tra, dat, spe, err := opts.Config.Configure(Dependencies{
+	tra, dat, txms, crd, spe, err := opts.Config.Configure(Dependencies{
 		Context: ctx,
 		Logger:  logger,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to load Spectre configuration: %w", err)
 	}
+	sts, err := opts.Config.Spectre.ConfigureStatusServer(spectreConfig.StatusServerDependencies{
+		Context: ctx,
+		Spectre: spe,
+		Logger:  logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load status server configuration: %w", err)
+	}
+	met, err := opts.Config.Metrics.Configure(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics configuration: %w", err)
+	}
 
 	return &Services{
-		ctxCancel: ctxCancel,
-		Transport: tra,
-		Datastore: dat,
-		Spectre:   spe,
+		ctxCancel:   ctxCancel,
+		Transport:   tra,
+		Datastore:   dat,
+		TxManagers:  txms,
+		Coordinator: crd,
+		Spectre:     spe,
+		Status:      sts,
+		Metrics:     met,
 	}, nil
 }
 
@@ -147,9 +218,29 @@ func (s *Services) Start() error {
 	if err = s.Datastore.Start(); err != nil {
 		return err
 	}
+	for _, txm := range s.TxManagers {
+		if err = txm.Start(); err != nil {
+			return err
+		}
+	}
+	if s.Coordinator != nil {
+		if err = s.Coordinator.Start(); err != nil {
+			return err
+		}
+	}
 	if err = s.Spectre.Start(); err != nil {
 		return err
 	}
+	if s.Status != nil {
+		if err = s.Status.Start(); err != nil {
+			return err
+		}
+	}
+	if s.Metrics != nil {
+		if err = s.Metrics.Start(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -157,5 +248,17 @@ func (s *Services) CancelAndWait() {
 	s.ctxCancel()
 	s.Transport.Wait()
 	s.Datastore.Wait()
+	for _, txm := range s.TxManagers {
+		txm.Wait()
+	}
+	if s.Coordinator != nil {
+		s.Coordinator.Wait()
+	}
 	s.Spectre.Wait()
+	if s.Status != nil {
+		s.Status.Wait()
+	}
+	if s.Metrics != nil {
+		s.Metrics.Wait()
+	}
 }
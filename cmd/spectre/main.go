@@ -25,6 +25,7 @@ func main() {
 
 	rootCmd.AddCommand(
 		NewRunCmd(&opts),
+		NewStatusCmd(&opts),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -17,6 +17,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
 
@@ -64,25 +66,30 @@ func NewPushPriceCmd(opts *options) *cobra.Command {
 				}
 			}
 
-			// Read JSON and parse it:
-			input, err := io.ReadAll(in)
-			if err != nil {
-				return err
-			}
+			// Read JSON messages and send them to the RPC client. The input
+			// may contain multiple messages, e.g. in the NDJSON format:
+			dec := json.NewDecoder(in)
+			for {
+				var raw json.RawMessage
+				err = dec.Decode(&raw)
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
 
-			msg := &messages.Price{}
-			err = msg.Unmarshall(input)
-			if err != nil {
-				return err
-			}
+				msg := &messages.Price{}
+				err = msg.Unmarshall(raw)
+				if err != nil {
+					return err
+				}
 
-			// Send price message to RPC client:
-			err = srv.Client.PublishPrice(msg)
-			if err != nil {
-				return err
+				err = srv.Client.PublishPrice(msg)
+				if err != nil {
+					return err
+				}
 			}
-
-			return nil
 		},
 	}
 }
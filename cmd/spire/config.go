@@ -24,8 +24,10 @@ import (
 	"github.com/makerdao/oracle-suite/internal/config"
 	ethereumConfig "github.com/makerdao/oracle-suite/internal/config/ethereum"
 	feedsConfig "github.com/makerdao/oracle-suite/internal/config/feeds"
+	metricsConfig "github.com/makerdao/oracle-suite/internal/config/metrics"
 	spireConfig "github.com/makerdao/oracle-suite/internal/config/spire"
 	transportConfig "github.com/makerdao/oracle-suite/internal/config/transport"
+	"github.com/makerdao/oracle-suite/internal/metrics"
 	"github.com/makerdao/oracle-suite/pkg/datastore"
 	"github.com/makerdao/oracle-suite/pkg/log"
 	logLogrus "github.com/makerdao/oracle-suite/pkg/log/logrus"
@@ -37,6 +39,7 @@ type Config struct {
 	Transport transportConfig.Transport `json:"transport"`
 	Ethereum  ethereumConfig.Ethereum   `json:"ethereum"`
 	Spire     spireConfig.Spire         `json:"spire"`
+	Metrics   metricsConfig.Metrics     `json:"metrics"`
 	Feeds     feedsConfig.Feeds         `json:"feeds"`
 }
 
@@ -158,6 +161,7 @@ type AgentServices struct {
 	Transport transport.Transport
 	Datastore datastore.Datastore
 	Agent     *spire.Agent
+	Metrics   *metrics.Server
 }
 
 func PrepareAgentServices(ctx context.Context, opts *options) (*AgentServices, error) {
@@ -193,12 +197,17 @@ func PrepareAgentServices(ctx context.Context, opts *options) (*AgentServices, e
 	if err != nil {
 		return nil, fmt.Errorf("failed to load Spire configuration: %w", err)
 	}
+	met, err := opts.Config.Metrics.Configure(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics configuration: %w", err)
+	}
 
 	return &AgentServices{
 		ctxCancel: ctxCancel,
 		Transport: tra,
 		Datastore: dat,
 		Agent:     age,
+		Metrics:   met,
 	}, nil
 }
 
@@ -213,6 +222,11 @@ func (s *AgentServices) Start() error {
 	if err = s.Agent.Start(); err != nil {
 		return err
 	}
+	if s.Metrics != nil {
+		if err = s.Metrics.Start(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -221,4 +235,7 @@ func (s *AgentServices) CancelAndWait() {
 	s.Transport.Wait()
 	s.Datastore.Wait()
 	s.Agent.Wait()
+	if s.Metrics != nil {
+		s.Metrics.Wait()
+	}
 }
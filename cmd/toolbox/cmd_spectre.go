@@ -17,12 +17,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/makerdao/oracle-suite/internal/config"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	oracleGeth "github.com/makerdao/oracle-suite/pkg/oracle/geth"
+	"github.com/makerdao/oracle-suite/pkg/spectre"
 )
 
 func NewSpectreCmd(opts *options) *cobra.Command {
@@ -35,6 +41,7 @@ func NewSpectreCmd(opts *options) *cobra.Command {
 
 	cmd.AddCommand(
 		NewSpectreMedianCmd(opts),
+		NewSpectreCostsCmd(opts),
 	)
 
 	return cmd
@@ -56,6 +63,7 @@ func NewSpectreMedianCmd(opts *options) *cobra.Command {
 					args = append(args, name)
 				}
 			}
+			clients := map[string]ethereum.Client{"": srv.Client}
 			for _, name := range args {
 				config, ok := opts.Config.Medianizers()[name]
 				if !ok {
@@ -63,7 +71,16 @@ func NewSpectreMedianCmd(opts *options) *cobra.Command {
 					continue
 				}
 
-				median := oracleGeth.NewMedian(srv.Client, ethereum.HexToAddress(config.Contract))
+				cli, ok := clients[config.Chain]
+				if !ok {
+					cli, err = opts.Config.ConfigureChainClient(config.Chain)
+					if err != nil {
+						return fmt.Errorf("invalid configuration for the %s medianizer: %w", name, err)
+					}
+					clients[config.Chain] = cli
+				}
+
+				median := oracleGeth.NewMedian(cli, ethereum.HexToAddress(config.Contract))
 				ctx := context.Background()
 				wat, err := median.Wat(ctx)
 				if err != nil {
@@ -102,3 +119,71 @@ func NewSpectreMedianCmd(opts *options) *cobra.Command {
 		},
 	}
 }
+
+func NewSpectreCostsCmd(opts *options) *cobra.Command {
+	var from, to string
+	cmd := &cobra.Command{
+		Use:   "costs [pairs...]",
+		Args:  cobra.MinimumNArgs(0),
+		Short: "returns the daily costs of pokes recorded by spectre",
+		Long:  ``,
+		RunE: func(_ *cobra.Command, args []string) error {
+			err := config.ParseFile(&opts.Config, opts.ConfigFilePath)
+			if err != nil {
+				return fmt.Errorf("failed to parse configuration file: %w", err)
+			}
+			for _, d := range []string{from, to} {
+				if _, err := time.Parse(spectre.CostDayFormat, d); d != "" && err != nil {
+					return fmt.Errorf("invalid day %s, expected the YYYY-MM-DD format", d)
+				}
+			}
+			store := opts.Config.Spectre.ConfigureCosts()
+			if store == nil {
+				return errors.New("the costs file is not configured")
+			}
+			costs, err := store.Load()
+			if err != nil {
+				return err
+			}
+			if len(args) == 0 {
+				for name := range costs {
+					args = append(args, name)
+				}
+				sort.Strings(args)
+			}
+			for _, name := range args {
+				var days []string
+				for day := range costs[name] {
+					if (from == "" || day >= from) && (to == "" || day <= to) {
+						days = append(days, day)
+					}
+				}
+				sort.Strings(days)
+
+				var total spectre.Cost
+				fmt.Println(name)
+				for _, day := range days {
+					c := costs[name][day]
+					total = total.Add(c)
+					fmt.Printf("%s  pokes: %d  gas: %d  fee: %s wei\n", day, c.Pokes, c.GasUsed, formatWei(c.Fee))
+				}
+				fmt.Printf("Total  pokes: %d  gas: %d  fee: %s wei\n", total.Pokes, total.GasUsed, formatWei(total.Fee))
+				fmt.Print("\n")
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "first day to include, in the YYYY-MM-DD format")
+	cmd.Flags().StringVar(&to, "to", "", "last day to include, in the YYYY-MM-DD format")
+	return cmd
+}
+
+// formatWei returns the fee in wei. Medianizers may be deployed on chains
+// with different native tokens, so the fee is not converted to any of them.
+func formatWei(wei *big.Int) string {
+	if wei == nil {
+		return "0"
+	}
+	return wei.String()
+}
@@ -41,6 +41,20 @@ func (c *Config) Configure() (ethereum.Client, ethereum.Signer, error) {
 	return cli, sig, nil
 }
 
+// ConfigureChainClient returns the client for one of the additional chains
+// defined in the Spectre config.
+func (c *Config) ConfigureChainClient(name string) (ethereum.Client, error) {
+	chain, ok := c.Spectre.Chains[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown chain %s", name)
+	}
+	sig, err := chain.Ethereum.ConfigureSigner()
+	if err != nil {
+		return nil, err
+	}
+	return chain.Ethereum.ConfigureEthereumClient(sig)
+}
+
 func (c *Config) Medianizers() map[string]spectreConfig.Medianizer {
 	return c.Spectre.Medianizers
 }
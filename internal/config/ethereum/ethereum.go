@@ -19,10 +19,10 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/makerdao/oracle-suite/internal/rpcsplitter"
@@ -34,12 +34,25 @@ import (
 
 const splitterVirtualHost = "makerdao-splitter"
 
+const (
+	SuggestedFeeStrategy  = "suggested"
+	FixedFeeStrategy      = "fixed"
+	FeeHistoryFeeStrategy = "feeHistory"
+)
+
+const defaultFeeHistoryBlocks = 10
+const defaultFeeHistoryPercentile = 50
+
 var ethClientFactory = func(endpoints []string) (geth.EthClient, error) {
 	switch len(endpoints) {
 	case 0:
 		return nil, errors.New("missing address to a RPC client in the configuration file")
 	case 1:
-		return ethclient.Dial(endpoints[0])
+		rpcClient, err := rpc.Dial(endpoints[0])
+		if err != nil {
+			return nil, err
+		}
+		return geth.NewRPCEthClient(rpcClient), nil
 	default:
 		// TODO: pass logger
 		splitter, err := rpcsplitter.NewTransport(endpoints, splitterVirtualHost, nil, null.New())
@@ -53,18 +66,68 @@ var ethClientFactory = func(endpoints []string) (geth.EthClient, error) {
 		if err != nil {
 			return nil, err
 		}
-		return ethclient.NewClient(rpcClient), nil
+		return geth.NewRPCEthClient(rpcClient), nil
 	}
 }
 
 type Ethereum struct {
-	From     string      `json:"from"`
-	Keystore string      `json:"keystore"`
-	Password string      `json:"password"`
-	RPC      interface{} `json:"rpc"`
+	From         string       `json:"from"`
+	Keystore     string       `json:"keystore"`
+	Password     string       `json:"password"`
+	RemoteSigner RemoteSigner `json:"remoteSigner"`
+	RPC          interface{}  `json:"rpc"`
+	Fees         Fees         `json:"fees"`
+	// ChainID is the chain ID used to sign transactions. If zero, the network
+	// ID reported by the RPC node is used.
+	ChainID uint64 `json:"chainID"`
+	// MultiCall is the address of the multicall contract used to read
+	// multiple values in a single call. If empty, the address is selected
+	// based on the chain ID.
+	MultiCall string `json:"multicall"`
+}
+
+// Fees configures how fees and gas limits of sent transactions are
+// calculated. All fees are in gwei.
+type Fees struct {
+	// Strategy is the name of the fee strategy, either "suggested", "fixed"
+	// or "feeHistory". If empty, the "suggested" strategy is used.
+	Strategy string `json:"strategy"`
+	// PriorityFee is the priority fee used by the "fixed" strategy.
+	PriorityFee float64 `json:"priorityFee"`
+	// MaxFee is the maximum fee used by the "fixed" strategy.
+	MaxFee float64 `json:"maxFee"`
+	// Multiplier multiplies fees calculated by the "suggested" and
+	// "feeHistory" strategies.
+	Multiplier float64 `json:"multiplier"`
+	// Blocks is the number of recent blocks used by the "feeHistory"
+	// strategy. If zero, 10 blocks are used.
+	Blocks uint64 `json:"blocks"`
+	// Percentile is the percentile of priority fees paid in recent blocks
+	// used by the "feeHistory" strategy. If zero, the 50th percentile is
+	// used.
+	Percentile float64 `json:"percentile"`
+	// MaxFeeCeiling is the hard limit for the maximum fee of all
+	// transactions. If zero, fees are not limited.
+	MaxFeeCeiling float64 `json:"maxFeeCeiling"`
+	// GasLimitMargin is the margin in percent added to the estimated gas.
+	// If zero, the margin of 25% is used.
+	GasLimitMargin float64 `json:"gasLimitMargin"`
+}
+
+// RemoteSigner configures an external signer. If the URL is set, keys are
+// not read from the keystore, instead the "from" account is used to sign
+// data using the remote signer.
+type RemoteSigner struct {
+	// Type is the remote signer API, either "clef" or "web3signer".
+	Type string `json:"type"`
+	// URL is an address of the remote signer's JSON-RPC API.
+	URL string `json:"url"`
 }
 
 func (c *Ethereum) ConfigureSigner() (ethereum.Signer, error) {
+	if c.RemoteSigner.URL != "" {
+		return c.configureRemoteSigner()
+	}
 	account, err := c.configureAccount()
 	if err != nil {
 		return nil, err
@@ -87,11 +150,24 @@ func (c *Ethereum) ConfigureEthereumClient(signer ethereum.Signer) (*geth.Client
 	if len(endpoints) == 0 {
 		return nil, errors.New("value of the RPC key must be string or array of strings")
 	}
+	opts, err := c.Fees.configure()
+	if err != nil {
+		return nil, err
+	}
+	if c.ChainID != 0 {
+		opts = append(opts, geth.WithChainID(c.ChainID))
+	}
+	if c.MultiCall != "" {
+		if !ethereum.IsHexAddress(c.MultiCall) {
+			return nil, fmt.Errorf("invalid multicall address: %s", c.MultiCall)
+		}
+		opts = append(opts, geth.WithMultiCallAddress(ethereum.HexToAddress(c.MultiCall)))
+	}
 	client, err := ethClientFactory(endpoints)
 	if err != nil {
 		return nil, err
 	}
-	return geth.NewClient(client, signer), nil
+	return geth.NewClient(client, signer, opts...), nil
 }
 
 func (c *Ethereum) configureAccount() (*geth.Account, error) {
@@ -109,6 +185,20 @@ func (c *Ethereum) configureAccount() (*geth.Account, error) {
 	return account, nil
 }
 
+func (c *Ethereum) configureRemoteSigner() (ethereum.Signer, error) {
+	if c.From == "" {
+		return nil, errors.New("the from address is required when using a remote signer")
+	}
+	if c.Keystore != "" {
+		return nil, errors.New("the keystore must not be used together with a remote signer")
+	}
+	client, err := rpc.DialHTTP(c.RemoteSigner.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the remote signer: %w", err)
+	}
+	return geth.NewRemoteSigner(client, c.RemoteSigner.Type, ethereum.HexToAddress(c.From))
+}
+
 func (c *Ethereum) readAccountPassphrase(path string) (string, error) {
 	if path == "" {
 		return "", nil
@@ -119,3 +209,57 @@ func (c *Ethereum) readAccountPassphrase(path string) (string, error) {
 	}
 	return strings.TrimSuffix(string(passphrase), "\n"), nil
 }
+
+func (c *Fees) configure() ([]geth.ClientOption, error) {
+	var opts []geth.ClientOption
+	switch c.Strategy {
+	case "", SuggestedFeeStrategy:
+		if c.Multiplier != 0 {
+			opts = append(opts, geth.WithFeeStrategy(geth.SuggestedFees{Multiplier: c.Multiplier}))
+		}
+	case FixedFeeStrategy:
+		if c.PriorityFee <= 0 || c.MaxFee <= 0 {
+			return nil, errors.New("the fixed fee strategy requires the priorityFee and maxFee values")
+		}
+		opts = append(opts, geth.WithFeeStrategy(geth.FixedFees{
+			PriorityFee: gweiToWei(c.PriorityFee),
+			MaxFee:      gweiToWei(c.MaxFee),
+		}))
+	case FeeHistoryFeeStrategy:
+		s := geth.FeeHistoryFees{
+			Blocks:     c.Blocks,
+			Percentile: c.Percentile,
+			Multiplier: c.Multiplier,
+		}
+		if s.Blocks == 0 {
+			s.Blocks = defaultFeeHistoryBlocks
+		}
+		if s.Percentile == 0 {
+			s.Percentile = defaultFeeHistoryPercentile
+		}
+		opts = append(opts, geth.WithFeeStrategy(s))
+	default:
+		return nil, fmt.Errorf("unknown fee strategy: %s", c.Strategy)
+	}
+	if c.MaxFeeCeiling > 0 {
+		opts = append(opts, geth.WithMaxFee(gweiToWei(c.MaxFeeCeiling)))
+	}
+	if c.GasLimitMargin > 0 {
+		opts = append(opts, geth.WithGasLimitMargin(c.GasLimitMargin))
+	}
+	return opts, nil
+}
+
+// Ceiling returns the hard limit for the maximum fee in wei or nil if
+// fees are not limited.
+func (c *Fees) Ceiling() *big.Int {
+	if c.MaxFeeCeiling <= 0 {
+		return nil
+	}
+	return gweiToWei(c.MaxFeeCeiling)
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(1e9)).Int(nil)
+	return wei
+}
@@ -16,12 +16,18 @@
 package ethereum
 
 import (
+	"context"
 	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/ethereum/geth"
 	"github.com/makerdao/oracle-suite/pkg/ethereum/geth/mocks"
 )
@@ -68,6 +74,28 @@ func TestEthereum_ConfigureSigner_WithPassword(t *testing.T) {
 	)
 }
 
+func TestEthereum_ConfigureSigner_RemoteSigner(t *testing.T) {
+	config := Ethereum{
+		From:         "0x07a35a1d4b751a818d93aa38e615c0df23064881",
+		RemoteSigner: RemoteSigner{Type: geth.ClefAPI, URL: "http://localhost:8550"},
+	}
+
+	signer, err := config.ConfigureSigner()
+	require.NoError(t, err)
+
+	assert.IsType(t, &geth.RemoteSigner{}, signer)
+	assert.Equal(t, "0x07a35a1d4b751a818d93aa38e615c0df23064881", strings.ToLower(signer.Address().String()))
+}
+
+func TestEthereum_ConfigureSigner_RemoteSignerWithoutFrom(t *testing.T) {
+	config := Ethereum{
+		RemoteSigner: RemoteSigner{Type: geth.ClefAPI, URL: "http://localhost:8550"},
+	}
+
+	_, err := config.ConfigureSigner()
+	assert.Error(t, err)
+}
+
 func TestEthereum_ConfigureEthereumClient(t *testing.T) {
 	prevEthClientFactory := ethClientFactory
 	defer func() { ethClientFactory = prevEthClientFactory }()
@@ -114,3 +142,68 @@ func TestEthereum_ConfigureEthereumClientWithMultipleEndpoints(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, client)
 }
+
+func TestEthereum_ConfigureEthereumClientInvalidMultiCall(t *testing.T) {
+	prevEthClientFactory := ethClientFactory
+	defer func() { ethClientFactory = prevEthClientFactory }()
+	ethClientFactory = func(endpoints []string) (geth.EthClient, error) {
+		return &mocks.EthClient{}, nil
+	}
+
+	config := Ethereum{
+		RPC:       "1.2.3.4:1234",
+		ChainID:   10,
+		MultiCall: "invalid",
+	}
+
+	_, err := config.ConfigureEthereumClient(nil)
+	assert.Error(t, err)
+}
+
+func TestFees_Configure(t *testing.T) {
+	ethClient := &mocks.EthClient{}
+	ethClient.On("FeeHistory", mock.Anything, uint64(10), (*big.Int)(nil), []float64{50}).Return(&ethereum.FeeHistory{
+		Reward:  [][]*big.Int{{big.NewInt(2e9)}},
+		BaseFee: []*big.Int{big.NewInt(100e9), big.NewInt(100e9)},
+	}, nil)
+
+	tests := []struct {
+		fees        Fees
+		priorityFee *big.Int
+		maxFee      *big.Int
+		wantErr     bool
+	}{
+		{
+			fees:        Fees{Strategy: "fixed", PriorityFee: 1.5, MaxFee: 100},
+			priorityFee: big.NewInt(1.5e9),
+			maxFee:      big.NewInt(100e9),
+		},
+		{
+			fees:        Fees{Strategy: "feeHistory", MaxFeeCeiling: 150},
+			priorityFee: big.NewInt(2e9),
+			maxFee:      big.NewInt(150e9),
+		},
+		{
+			fees:    Fees{Strategy: "fixed"},
+			wantErr: true,
+		},
+		{
+			fees:    Fees{Strategy: "unknown"},
+			wantErr: true,
+		},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
+			opts, err := tt.fees.configure()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			priorityFee, maxFee, err := geth.NewClient(ethClient, nil, opts...).SuggestFees(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.priorityFee, priorityFee)
+			assert.Equal(t, tt.maxFee, maxFee)
+		})
+	}
+}
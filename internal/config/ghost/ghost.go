@@ -17,12 +17,20 @@ package ghost
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/makerdao/oracle-suite/pkg/datastore"
+	datastoreMemory "github.com/makerdao/oracle-suite/pkg/datastore/memory"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/ghost"
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 	"github.com/makerdao/oracle-suite/pkg/log"
+	"github.com/makerdao/oracle-suite/pkg/starkex"
 	"github.com/makerdao/oracle-suite/pkg/transport"
 )
 
@@ -31,9 +39,55 @@ var ghostFactory = func(ctx context.Context, cfg ghost.Config) (*ghost.Ghost, er
 	return ghost.NewGhost(ctx, cfg)
 }
 
+var datastoreFactory = func(ctx context.Context, cfg datastoreMemory.Config) (datastore.Datastore, error) {
+	return datastoreMemory.NewDatastore(ctx, cfg)
+}
+
 type Ghost struct {
-	Interval int      `json:"interval"`
-	Pairs    []string `json:"pairs"`
+	Interval           int               `json:"interval"`
+	Pairs              []string          `json:"pairs"`
+	Policies           map[string]Policy `json:"policies"`
+	SigningConcurrency int               `json:"signingConcurrency"`
+	// FetchTimeout is the maximum time in seconds to wait for prices in
+	// a single cycle. If zero, the shortest interval of all pairs is used.
+	FetchTimeout int `json:"fetchTimeout"`
+	// StarkKey is a path to a file with a hex encoded stark private key.
+	// If set, prices are also signed using the StarkEx signature.
+	StarkKey string `json:"starkKey"`
+	// StateFile is a path to a file in which the last signed and broadcast
+	// prices are stored. If empty, the state is not persisted across restarts.
+	StateFile string `json:"stateFile"`
+	// Bundle enables sending prices as a single signed price bundle instead
+	// of separate price messages.
+	Bundle bool `json:"bundle"`
+}
+
+type Policy struct {
+	// Interval overrides the global interval for the pair, in seconds.
+	Interval int `json:"interval"`
+	// MaxSourceAge is the maximum age in seconds of the oldest source used
+	// to calculate the price.
+	MaxSourceAge int `json:"maxSourceAge"`
+	// Heartbeat is the maximum time in seconds between two broadcasts. If
+	// zero, prices are broadcast only when they exceed the Deviation, or on
+	// every interval if the Deviation is zero too.
+	Heartbeat int `json:"heartbeat"`
+	// Deviation is the price change in percent which causes an immediate
+	// broadcast.
+	Deviation float64 `json:"deviation"`
+	// MaxOwnDeviation is the maximum price change in percent since the last
+	// broadcast. Prices which deviate more are withheld.
+	MaxOwnDeviation float64 `json:"maxOwnDeviation"`
+	// OwnDeviationConfirmations is the number of consistent prices after
+	// which a price exceeding the MaxOwnDeviation is accepted. If zero,
+	// 3 prices are required.
+	OwnDeviationConfirmations int `json:"ownDeviationConfirmations"`
+	// MaxPeerDeviation is the maximum difference in percent from the median
+	// of other feeders' prices. Prices which deviate more are withheld.
+	MaxPeerDeviation float64 `json:"maxPeerDeviation"`
+	// PeerPriceExpiration is the maximum age in seconds of other feeders'
+	// prices used to calculate the median.
+	PeerPriceExpiration int `json:"peerPriceExpiration"`
 }
 
 type Dependencies struct {
@@ -41,17 +95,120 @@ type Dependencies struct {
 	Gofer     gofer.Gofer
 	Signer    ethereum.Signer
 	Transport transport.Transport
+	Datastore datastore.Datastore
+	Logger    log.Logger
+}
+
+type DatastoreDependencies struct {
+	Context   context.Context
+	Signer    ethereum.Signer
+	Transport transport.Transport
+	Feeds     []ethereum.Address
 	Logger    log.Logger
 }
 
 func (c *Ghost) Configure(d Dependencies) (*ghost.Ghost, error) {
+	// Pairs with a policy are broadcast even if they are not listed in
+	// the pairs list:
+	pairs := append([]string{}, c.Pairs...)
+	policies := make(map[string]ghost.Policy)
+	for _, pair := range sortedPolicyPairs(c.Policies) {
+		p := c.Policies[pair]
+		policies[pair] = ghost.Policy{
+			Interval:                  time.Second * time.Duration(p.Interval),
+			MaxSourceAge:              time.Second * time.Duration(p.MaxSourceAge),
+			Heartbeat:                 time.Second * time.Duration(p.Heartbeat),
+			Deviation:                 p.Deviation,
+			MaxOwnDeviation:           p.MaxOwnDeviation,
+			OwnDeviationConfirmations: p.OwnDeviationConfirmations,
+			MaxPeerDeviation:          p.MaxPeerDeviation,
+			PeerPriceExpiration:       time.Second * time.Duration(p.PeerPriceExpiration),
+		}
+		if !containsPair(pairs, pair) {
+			pairs = append(pairs, pair)
+		}
+	}
+	starkKey, err := c.readStarkKey()
+	if err != nil {
+		return nil, err
+	}
 	cfg := ghost.Config{
-		Gofer:     d.Gofer,
+		Gofer:              d.Gofer,
+		Signer:             d.Signer,
+		StarkKey:           starkKey,
+		State:              c.ConfigureState(),
+		Transport:          d.Transport,
+		Datastore:          d.Datastore,
+		Logger:             d.Logger,
+		Interval:           time.Second * time.Duration(c.Interval),
+		Pairs:              pairs,
+		Policies:           policies,
+		FetchTimeout:       time.Second * time.Duration(c.FetchTimeout),
+		SigningConcurrency: c.SigningConcurrency,
+		Bundle:             c.Bundle,
+	}
+	return ghostFactory(d.Context, cfg)
+}
+
+// ConfigureState returns a store for the last signed and broadcast
+// prices. It returns nil if the state file is not configured.
+func (c *Ghost) ConfigureState() ghost.StateStore {
+	if c.StateFile == "" {
+		return nil
+	}
+	return ghost.NewFileStateStore(c.StateFile)
+}
+
+// ConfigureDatastore returns a datastore with prices sent by other feeders,
+// which is used to compare prices with the peers' median. It returns nil
+// if none of the policies uses the peers' median.
+func (c *Ghost) ConfigureDatastore(d DatastoreDependencies) (datastore.Datastore, error) {
+	cfg := datastoreMemory.Config{
 		Signer:    d.Signer,
 		Transport: d.Transport,
+		Pairs:     make(map[string]*datastoreMemory.Pair),
 		Logger:    d.Logger,
-		Interval:  time.Second * time.Duration(c.Interval),
-		Pairs:     c.Pairs,
 	}
-	return ghostFactory(d.Context, cfg)
+	for pair, p := range c.Policies {
+		if p.MaxPeerDeviation > 0 {
+			cfg.Pairs[pair] = &datastoreMemory.Pair{Feeds: d.Feeds}
+		}
+	}
+	if len(cfg.Pairs) == 0 {
+		return nil, nil
+	}
+	return datastoreFactory(d.Context, cfg)
+}
+
+func (c *Ghost) readStarkKey() (*starkex.PrivateKey, error) {
+	if c.StarkKey == "" {
+		return nil, nil
+	}
+	b, err := ioutil.ReadFile(c.StarkKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stark key file: %w", err)
+	}
+	d, ok := new(big.Int).SetString(strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse stark key file: %s", c.StarkKey)
+	}
+	return starkex.NewPrivateKey(d)
+}
+
+func sortedPolicyPairs(policies map[string]Policy) []string {
+	var pairs []string
+	for pair := range policies {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+func containsPair(pairs []string, pair string) bool {
+	for _, p := range pairs {
+		if p == pair {
+			return true
+		}
+	}
+	return false
 }
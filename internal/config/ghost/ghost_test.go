@@ -17,12 +17,17 @@ package ghost
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/makerdao/oracle-suite/pkg/datastore"
+	datastoreMemory "github.com/makerdao/oracle-suite/pkg/datastore/memory"
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	ethereumMocks "github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
 	"github.com/makerdao/oracle-suite/pkg/ghost"
 	goferMocks "github.com/makerdao/oracle-suite/pkg/gofer/mocks"
@@ -42,14 +47,28 @@ func TestGhost_Configure(t *testing.T) {
 	logger := null.New()
 
 	config := Ghost{
-		Interval: interval,
-		Pairs:    pairs,
+		Interval:           interval,
+		SigningConcurrency: 4,
+		FetchTimeout:       5,
+		Bundle:             true,
+		Pairs:              pairs,
+		Policies: map[string]Policy{
+			"AAABBB": {Heartbeat: 3600, Deviation: 0.5},
+			"CCCDDD": {Interval: 5, MaxSourceAge: 30},
+		},
 	}
 
 	ghostFactory = func(ctx context.Context, cfg ghost.Config) (*ghost.Ghost, error) {
 		assert.NotNil(t, ctx)
 		assert.Equal(t, time.Duration(interval)*time.Second, cfg.Interval)
-		assert.Equal(t, pairs, cfg.Pairs)
+		assert.Equal(t, []string{"AAABBB", "XXXYYY", "CCCDDD"}, cfg.Pairs)
+		assert.Equal(t, map[string]ghost.Policy{
+			"AAABBB": {Heartbeat: time.Hour, Deviation: 0.5},
+			"CCCDDD": {Interval: 5 * time.Second, MaxSourceAge: 30 * time.Second},
+		}, cfg.Policies)
+		assert.Equal(t, 4, cfg.SigningConcurrency)
+		assert.Equal(t, 5*time.Second, cfg.FetchTimeout)
+		assert.True(t, cfg.Bundle)
 		assert.Equal(t, signer, cfg.Signer)
 		assert.Equal(t, transport, cfg.Transport)
 		assert.Equal(t, logger, cfg.Logger)
@@ -67,3 +86,81 @@ func TestGhost_Configure(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, g)
 }
+
+func TestGhost_Configure_StarkKey(t *testing.T) {
+	prevGhostFactory := ghostFactory
+	defer func() { ghostFactory = prevGhostFactory }()
+
+	f, err := ioutil.TempFile("", "stark")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("0x03c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	config := Ghost{StarkKey: f.Name()}
+
+	ghostFactory = func(ctx context.Context, cfg ghost.Config) (*ghost.Ghost, error) {
+		require.NotNil(t, cfg.StarkKey)
+		assert.Equal(t, "77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43", cfg.StarkKey.PublicKey().Text(16))
+
+		return &ghost.Ghost{}, nil
+	}
+
+	_, err = config.Configure(Dependencies{
+		Context:   context.Background(),
+		Gofer:     &goferMocks.Gofer{},
+		Signer:    &ethereumMocks.Signer{},
+		Transport: local.New(context.Background(), 0, nil),
+		Logger:    null.New(),
+	})
+	require.NoError(t, err)
+}
+
+func TestGhost_ConfigureDatastore(t *testing.T) {
+	prevDatastoreFactory := datastoreFactory
+	defer func() { datastoreFactory = prevDatastoreFactory }()
+
+	signer := &ethereumMocks.Signer{}
+	transport := local.New(context.Background(), 0, nil)
+	feeds := []ethereum.Address{ethereum.HexToAddress("0x07a35a1d4b751a818d93aa38e615c0df23064881")}
+	logger := null.New()
+
+	config := Ghost{
+		Pairs: []string{"AAABBB", "XXXYYY"},
+		Policies: map[string]Policy{
+			"AAABBB": {MaxPeerDeviation: 5},
+			"XXXYYY": {MaxOwnDeviation: 5},
+		},
+	}
+
+	datastoreFactory = func(ctx context.Context, cfg datastoreMemory.Config) (datastore.Datastore, error) {
+		assert.NotNil(t, ctx)
+		assert.Equal(t, signer, cfg.Signer)
+		assert.Equal(t, transport, cfg.Transport)
+		assert.Equal(t, map[string]*datastoreMemory.Pair{"AAABBB": {Feeds: feeds}}, cfg.Pairs)
+		return &datastoreMemory.Datastore{}, nil
+	}
+
+	ds, err := config.ConfigureDatastore(DatastoreDependencies{
+		Context:   context.Background(),
+		Signer:    signer,
+		Transport: transport,
+		Feeds:     feeds,
+		Logger:    logger,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, ds)
+
+	// Without policies which use the peers' median, the datastore is not
+	// needed:
+	config.Policies = nil
+	ds, err = config.ConfigureDatastore(DatastoreDependencies{Context: context.Background()})
+	require.NoError(t, err)
+	assert.Nil(t, ds)
+}
+
+func TestGhost_ConfigureState(t *testing.T) {
+	assert.Nil(t, (&Ghost{}).ConfigureState())
+	assert.IsType(t, &ghost.FileStateStore{}, (&Ghost{StateFile: "state.json"}).ConfigureState())
+}
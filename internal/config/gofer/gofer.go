@@ -30,6 +30,7 @@ import (
 	"github.com/makerdao/oracle-suite/pkg/gofer/graph"
 	"github.com/makerdao/oracle-suite/pkg/gofer/graph/feeder"
 	"github.com/makerdao/oracle-suite/pkg/gofer/graph/nodes"
+	"github.com/makerdao/oracle-suite/pkg/gofer/httpapi"
 	"github.com/makerdao/oracle-suite/pkg/gofer/origins"
 	"github.com/makerdao/oracle-suite/pkg/gofer/rpc"
 	"github.com/makerdao/oracle-suite/pkg/log"
@@ -37,6 +38,7 @@ import (
 
 const defaultTTL = 60 * time.Second
 const maxTTL = 60 * time.Second
+const defaultHistorySize = 1000
 
 type ErrCyclicReference struct {
 	Pair gofer.Pair
@@ -63,6 +65,8 @@ func (e ErrCyclicReference) Error() string {
 
 type Gofer struct {
 	RPC         RPC                   `json:"rpc"`
+	HTTP        HTTP                  `json:"http"`
+	History     History               `json:"history"`
 	EthRPC      string                `json:"ethRpc"`
 	Origins     map[string]Origin     `json:"origins"`
 	PriceModels map[string]PriceModel `json:"priceModels"`
@@ -72,6 +76,20 @@ type RPC struct {
 	Address string `json:"address"`
 }
 
+type HTTP struct {
+	Address    string `json:"address"`
+	EnableCORS bool   `json:"enableCors"`
+}
+
+// History configures price history kept for each origin. The history is
+// disabled if both fields are zero.
+type History struct {
+	// Size is a maximum number of prices kept for each origin.
+	Size int `json:"size"`
+	// Retention is a time in seconds after which prices are discarded.
+	Retention int `json:"retention"`
+}
+
 type Origin struct {
 	Type   string          `json:"type"`
 	Name   string          `json:"name"`
@@ -107,8 +125,13 @@ func (c *Gofer) ConfigureGofer(
 	return c.configureRPCClient(ctx)
 }
 
-// ConfigureRPCAgent returns a new rpc.Agent instance.
-func (c *Gofer) ConfigureRPCAgent(ctx context.Context, cli pkgEthereum.Client, logger log.Logger) (*rpc.Agent, error) {
+// ConfigureAsyncGofer returns a new graph.AsyncGofer instance which is used
+// by agents.
+func (c *Gofer) ConfigureAsyncGofer(
+	ctx context.Context,
+	cli pkgEthereum.Client,
+	logger log.Logger) (*graph.AsyncGofer, error) {
+
 	gra, err := c.buildGraphs()
 	if err != nil {
 		return nil, fmt.Errorf("unable to load price models: %w", err)
@@ -121,8 +144,13 @@ func (c *Gofer) ConfigureRPCAgent(ctx context.Context, cli pkgEthereum.Client, l
 	fed := feeder.NewFeeder(ctx, originSet, logger)
 	gof, err := graph.NewAsyncGofer(ctx, gra, fed)
 	if err != nil {
-		return nil, fmt.Errorf("unable to initialize RPC agent: %w", err)
+		return nil, fmt.Errorf("unable to initialize async gofer: %w", err)
 	}
+	return gof, nil
+}
+
+// ConfigureRPCAgent returns a new rpc.Agent instance.
+func (c *Gofer) ConfigureRPCAgent(ctx context.Context, gof gofer.Gofer, logger log.Logger) (*rpc.Agent, error) {
 	srv, err := rpc.NewAgent(ctx, rpc.AgentConfig{
 		Gofer:   gof,
 		Network: "tcp",
@@ -135,6 +163,24 @@ func (c *Gofer) ConfigureRPCAgent(ctx context.Context, cli pkgEthereum.Client, l
 	return srv, nil
 }
 
+// ConfigureHTTPServer returns a new httpapi.Server instance. If the HTTP
+// address is not configured, nil is returned.
+func (c *Gofer) ConfigureHTTPServer(ctx context.Context, gof gofer.Gofer, logger log.Logger) (*httpapi.Server, error) {
+	if c.HTTP.Address == "" {
+		return nil, nil
+	}
+	srv, err := httpapi.New(ctx, httpapi.Config{
+		Gofer:      gof,
+		Address:    c.HTTP.Address,
+		EnableCORS: c.HTTP.EnableCORS,
+		Logger:     logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize HTTP server: %w", err)
+	}
+	return srv, nil
+}
+
 // ConfigureGofer returns a new Gofer instance.
 func (c *Gofer) configureGofer(ctx context.Context, cli pkgEthereum.Client, logger log.Logger) (gofer.Gofer, error) {
 	gra, err := c.buildGraphs()
@@ -315,7 +361,15 @@ func (c *Gofer) originNode(model PriceModel, source Source) (nodes.Node, error)
 		ttl = time.Second * time.Duration(source.TTL)
 	}
 
-	return nodes.NewOriginNode(originPair, ttl, ttl+maxTTL), nil
+	node := nodes.NewOriginNode(originPair, ttl, ttl+maxTTL)
+	if c.History.Size > 0 || c.History.Retention > 0 {
+		size := defaultHistorySize
+		if c.History.Size > 0 {
+			size = c.History.Size
+		}
+		node.SetHistory(nodes.NewPriceHistory(size, time.Second*time.Duration(c.History.Retention)))
+	}
+	return node, nil
 }
 
 func (c *Gofer) detectCycle(graphs map[gofer.Pair]nodes.Aggregator) error {
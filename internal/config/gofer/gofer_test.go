@@ -282,3 +282,32 @@ func TestConfig_buildGraphs_MedianTTL(t *testing.T) {
 	assert.Equal(t, 180*time.Second, g[p].Children()[0].(*nodes.OriginNode).MaxTTL())
 	assert.Equal(t, 120*time.Second, g[p].Children()[0].(*nodes.OriginNode).MinTTL())
 }
+
+func TestConfig_buildGraphs_History(t *testing.T) {
+	config := Gofer{
+		History: History{Size: 2},
+		PriceModels: map[string]PriceModel{
+			"A/B": {
+				Method: "median",
+				Sources: [][]Source{
+					{
+						{Origin: "ab", Pair: "A/B"},
+					},
+				},
+			},
+		},
+	}
+
+	p, _ := gofer.NewPair("A/B")
+	g, _ := config.buildGraphs()
+	o := g[p].Children()[0].(*nodes.OriginNode)
+
+	for i := 0; i < 3; i++ {
+		err := o.Ingest(nodes.OriginPrice{
+			PairPrice: nodes.PairPrice{Pair: p, Price: float64(i), Time: time.Now()},
+			Origin:    "ab",
+		})
+		assert.NoError(t, err)
+	}
+	assert.Len(t, o.History(time.Time{}), 2)
+}
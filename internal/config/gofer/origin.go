@@ -18,6 +18,7 @@ package gofer
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/makerdao/oracle-suite/internal/query"
 	pkgEthereum "github.com/makerdao/oracle-suite/pkg/ethereum"
@@ -69,6 +70,36 @@ func parseParamsContracts(params json.RawMessage) (origins.ContractAddresses, er
 	return res.Contracts, nil
 }
 
+type pluginParams struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	Network string   `json:"network"`
+	Address string   `json:"address"`
+	Timeout int      `json:"timeout"`
+}
+
+func parseParamsPlugin(params json.RawMessage) (origins.Plugin, error) {
+	if params == nil {
+		return origins.Plugin{}, fmt.Errorf("invalid origin parameters")
+	}
+
+	var res pluginParams
+	err := json.Unmarshal(params, &res)
+	if err != nil {
+		return origins.Plugin{}, fmt.Errorf("failed to marshal origin plugin params: %w", err)
+	}
+	if res.Command == "" && res.Address == "" {
+		return origins.Plugin{}, origins.ErrInvalidPluginConfig
+	}
+	return origins.Plugin{
+		Command: res.Command,
+		Args:    res.Args,
+		Network: res.Network,
+		Address: res.Address,
+		Timeout: time.Second * time.Duration(res.Timeout),
+	}, nil
+}
+
 //nolint:funlen,gocyclo
 func NewHandler(
 	origin string,
@@ -155,6 +186,12 @@ func NewHandler(
 			origins.OpenExchangeRates{WorkerPool: wp, APIKey: apiKey},
 			aliases,
 		), nil
+	case "plugin":
+		plugin, err := parseParamsPlugin(params)
+		if err != nil {
+			return nil, err
+		}
+		return origins.NewBaseExchangeHandler(plugin, aliases), nil
 	case "poloniex":
 		return origins.NewBaseExchangeHandler(origins.Poloniex{WorkerPool: wp}, aliases), nil
 	case "sushiswap":
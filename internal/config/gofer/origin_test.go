@@ -17,6 +17,7 @@ package gofer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -54,3 +55,20 @@ func TestParsingOriginParamsAliases(t *testing.T) {
 	assert.NotNil(t, aliases)
 	assert.Equal(t, "WETH", aliases["ETH"])
 }
+
+func TestParsingOriginParamsPlugin(t *testing.T) {
+	plugin, err := parseParamsPlugin([]byte(`{"command":"/bin/plugin","args":["-v"],"timeout":5}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "/bin/plugin", plugin.Command)
+	assert.Equal(t, []string{"-v"}, plugin.Args)
+	assert.Equal(t, 5*time.Second, plugin.Timeout)
+
+	plugin, err = parseParamsPlugin([]byte(`{"network":"unix","address":"/tmp/plugin.sock"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "unix", plugin.Network)
+	assert.Equal(t, "/tmp/plugin.sock", plugin.Address)
+
+	// Either command or address is required:
+	_, err = parseParamsPlugin([]byte(`{}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/makerdao/oracle-suite/internal/metrics"
+	"github.com/makerdao/oracle-suite/pkg/log"
+)
+
+type Metrics struct {
+	// Address is a listen address of the HTTP server which exposes
+	// Prometheus metrics on the /metrics endpoint. If empty, metrics
+	// are not exposed.
+	Address string `json:"address"`
+}
+
+// Configure returns a metrics server or nil if the address is not
+// configured.
+func (c *Metrics) Configure(ctx context.Context, logger log.Logger) (*metrics.Server, error) {
+	if c.Address == "" {
+		return nil, nil
+	}
+	return metrics.NewServer(ctx, c.Address, logger)
+}
@@ -0,0 +1,40 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+func TestMetrics_Configure(t *testing.T) {
+	config := Metrics{Address: "127.0.0.1:0"}
+	srv, err := config.Configure(context.Background(), null.New())
+	require.NoError(t, err)
+	assert.NotNil(t, srv)
+}
+
+func TestMetrics_Configure_Disabled(t *testing.T) {
+	config := Metrics{}
+	srv, err := config.Configure(context.Background(), null.New())
+	require.NoError(t, err)
+	assert.Nil(t, srv)
+}
@@ -17,14 +17,20 @@ package spectre
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"time"
 
+	ethereumConfig "github.com/makerdao/oracle-suite/internal/config/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/datastore"
 	datastoreMemory "github.com/makerdao/oracle-suite/pkg/datastore/memory"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/txmanager"
 	"github.com/makerdao/oracle-suite/pkg/log"
+	"github.com/makerdao/oracle-suite/pkg/oracle"
 	oracleGeth "github.com/makerdao/oracle-suite/pkg/oracle/geth"
 	"github.com/makerdao/oracle-suite/pkg/spectre"
+	"github.com/makerdao/oracle-suite/pkg/spectre/httpapi"
 	"github.com/makerdao/oracle-suite/pkg/transport"
 )
 
@@ -33,20 +39,119 @@ var spectreFactory = func(ctx context.Context, cfg spectre.Config) (*spectre.Spe
 	return spectre.NewSpectre(ctx, cfg)
 }
 
+var txManagerFactory = func(ctx context.Context, cfg txmanager.Config) (*txmanager.TxManager, error) {
+	return txmanager.NewTxManager(ctx, cfg)
+}
+
+var coordinatorFactory = func(ctx context.Context, cfg spectre.CoordinatorConfig) (*spectre.Coordinator, error) {
+	return spectre.NewCoordinator(ctx, cfg)
+}
+
 var datastoreFactory = func(ctx context.Context, cfg datastoreMemory.Config) (datastore.Datastore, error) {
 	return datastoreMemory.NewDatastore(ctx, cfg)
 }
 
+var statusServerFactory = func(ctx context.Context, cfg httpapi.Config) (*httpapi.Server, error) {
+	return httpapi.New(ctx, cfg)
+}
+
 type Spectre struct {
 	Interval    int64                 `json:"interval"`
 	Medianizers map[string]Medianizer `json:"medianizers"`
+	// Transactions configures tracking of poke transactions.
+	Transactions Transactions `json:"transactions"`
+	// PokeMulticall is an optional address of the Multicall2 contract. If
+	// set, pokes for all medianizers on the default chain are sent in
+	// a single transaction. It is unrelated to the multicall contract in the
+	// "ethereum" section, which is used only to batch reads.
+	PokeMulticall string `json:"pokeMulticall"`
+	// Coordination configures the coordination between multiple Spectre
+	// instances.
+	Coordination Coordination `json:"coordination"`
+	// Chains is the list of additional chains, on which medianizers can be
+	// deployed. Medianizers use the default chain, configured in the
+	// "ethereum" section, unless they reference one of these chains.
+	Chains map[string]Chain `json:"chains"`
+	// Status configures the HTTP server which exposes the status of
+	// relayed pairs.
+	Status Status `json:"status"`
+	// Costs configures the accounting of poke costs.
+	Costs Costs `json:"costs"`
+}
+
+type Costs struct {
+	// File is a path to a file in which the daily costs of pokes are
+	// stored. If empty, costs are counted only since the start of Spectre.
+	File string `json:"file"`
+}
+
+type Status struct {
+	// Address is a listen address of the status server. If empty, the
+	// server is disabled.
+	Address string `json:"address"`
+}
+
+type Chain struct {
+	// Ethereum configures the RPC endpoints, signer, fees and chain ID.
+	Ethereum ethereumConfig.Ethereum `json:"ethereum"`
+	// PokeMulticall is an optional address of the Multicall2 contract. If
+	// set, pokes for all medianizers on this chain are sent in a single
+	// transaction. It is unrelated to the multicall contract in the
+	// "ethereum" section, which is used only to batch reads.
+	PokeMulticall string `json:"pokeMulticall"`
 }
 
 type Medianizer struct {
+	// Pair is the name of the asset pair. If empty, the medianizer name is
+	// used. It allows to relay the same asset pair to medianizers on
+	// different chains.
+	Pair string `json:"pair"`
+	// Chain is the name of the chain on which the medianizer is deployed.
+	// If empty, the default chain is used.
+	Chain            string  `json:"chain"`
 	Contract         string  `json:"oracle"`
 	OracleSpread     float64 `json:"oracleSpread"`
 	OracleExpiration int64   `json:"oracleExpiration"`
 	MsgExpiration    int64   `json:"msgExpiration"`
+	// PriceSelection is the strategy used to select prices sent to the
+	// Oracle, either "freshest" or "closestToMedian". If empty, the
+	// "freshest" strategy is used.
+	PriceSelection string `json:"priceSelection"`
+	// OSM is an optional address of the OSM contract which reads prices
+	// from the medianizer. If set, the OSM is poked once its hop has passed.
+	OSM string `json:"osm"`
+	// DailyBudget is the maximum amount of ETH spent on pokes during
+	// a single day (UTC). Once exceeded, the medianizer is updated only when
+	// its price expires. If zero, the budget is unlimited.
+	DailyBudget float64 `json:"dailyBudget"`
+}
+
+const (
+	FreshestPriceSelection        = "freshest"
+	ClosestToMedianPriceSelection = "closestToMedian"
+)
+
+type Transactions struct {
+	// ResendAfterBlocks is the number of blocks after which a poke
+	// transaction that was not mined is resent with bumped fees.
+	ResendAfterBlocks uint64 `json:"resendAfterBlocks"`
+	// FeeBump is the fee increase in percent used to resend transactions.
+	FeeBump float64 `json:"feeBump"`
+	// Interval describes how often pending transactions are checked,
+	// in seconds.
+	Interval int64 `json:"interval"`
+}
+
+type Coordination struct {
+	// Relayers is the list of addresses of all coordinated relayers. If
+	// empty, the coordination is disabled.
+	Relayers []string `json:"relayers"`
+	// SlotDuration is the duration of a single relayer's turn, in seconds.
+	SlotDuration int64 `json:"slotDuration"`
+	// FallbackDelay is the time after which the next relayer takes over
+	// if the designated relayer remains silent, in seconds. It must be
+	// shorter than the slot duration divided by the number of other relayers.
+	FallbackDelay int64 `json:"fallbackDelay"`
 }
 
 type Dependencies struct {
@@ -54,8 +159,47 @@ type Dependencies struct {
 	Signer         ethereum.Signer
 	Datastore      datastore.Datastore
 	EthereumClient ethereum.Client
-	Feeds          []ethereum.Address
+	TxManager      *txmanager.TxManager
+	Coordinator    *spectre.Coordinator
+	// Chains contains dependencies for additional chains, returned by
+	// the ConfigureChains method.
+	Chains map[string]ChainDependencies
+	Feeds  []ethereum.Address
+	Logger log.Logger
+}
+
+type ChainDependencies struct {
+	Signer         ethereum.Signer
+	EthereumClient ethereum.Client
+	TxManager      *txmanager.TxManager
+}
+
+type ChainsDependencies struct {
+	Context context.Context
+	Logger  log.Logger
+}
+
+type TxManagerDependencies struct {
+	Context        context.Context
+	Signer         ethereum.Signer
+	EthereumClient ethereum.Client
 	Logger         log.Logger
+	// MaxFee is the maximum fee ceiling of the EthereumClient, nil if fees
+	// are not limited.
+	MaxFee *big.Int
+}
+
+type CoordinatorDependencies struct {
+	Context   context.Context
+	Signer    ethereum.Signer
+	Transport transport.Transport
+	Logger    log.Logger
+}
+
+type StatusServerDependencies struct {
+	Context context.Context
+	Spectre *spectre.Spectre
+	Logger  log.Logger
 }
 
 type DatastoreDependencies struct {
@@ -68,23 +212,164 @@ type DatastoreDependencies struct {
 
 func (c *Spectre) ConfigureSpectre(d Dependencies) (*spectre.Spectre, error) {
 	cfg := spectre.Config{
-		Signer:    d.Signer,
-		Interval:  time.Second * time.Duration(c.Interval),
-		Datastore: d.Datastore,
-		Logger:    d.Logger,
+		Signer:      d.Signer,
+		Interval:    time.Second * time.Duration(c.Interval),
+		Datastore:   d.Datastore,
+		Coordinator: d.Coordinator,
+		Costs:       c.ConfigureCosts(),
+		Logger:      d.Logger,
+	}
+	chains := map[string]ChainDependencies{"": {
+		Signer:         d.Signer,
+		EthereumClient: d.EthereumClient,
+		TxManager:      d.TxManager,
+	}}
+	for name, chain := range d.Chains {
+		chains[name] = chain
+	}
+	multicalls := make(map[string]oracle.Multicall)
+	for name, chain := range chains {
+		addr := c.PokeMulticall
+		if name != "" {
+			addr = c.Chains[name].PokeMulticall
+		}
+		if addr != "" {
+			multicalls[name] = oracleGeth.NewMulticall(chain.client(), ethereum.HexToAddress(addr))
+		}
 	}
 	for name, pair := range c.Medianizers {
+		selection, err := pair.priceSelection()
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for the %s medianizer: %w", name, err)
+		}
+		budget, err := pair.dailyBudget()
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for the %s medianizer: %w", name, err)
+		}
+		chain, ok := chains[pair.Chain]
+		if !ok {
+			return nil, fmt.Errorf("invalid configuration for the %s medianizer: unknown chain %s", name, pair.Chain)
+		}
+		cli := chain.client()
+		var osm oracle.OSM
+		if pair.OSM != "" {
+			osm = oracleGeth.NewOSM(cli, ethereum.HexToAddress(pair.OSM))
+		}
 		cfg.Pairs = append(cfg.Pairs, &spectre.Pair{
-			AssetPair:        name,
+			Name:             name,
+			AssetPair:        pair.assetPair(name),
 			OracleSpread:     pair.OracleSpread,
 			OracleExpiration: time.Second * time.Duration(pair.OracleExpiration),
 			PriceExpiration:  time.Second * time.Duration(pair.MsgExpiration),
-			Median:           oracleGeth.NewMedian(d.EthereumClient, ethereum.HexToAddress(pair.Contract)),
+			PriceSelection:   selection,
+			Median:           oracleGeth.NewMedian(cli, ethereum.HexToAddress(pair.Contract)),
+			OSM:              osm,
+			TxManager:        chain.TxManager,
+			Multicall:        multicalls[pair.Chain],
+			DailyBudget:      budget,
 		})
 	}
 	return spectreFactory(d.Context, cfg)
 }
 
+// ConfigureCosts returns a store for the daily costs of pokes. It returns
+// nil if the costs file is not configured.
+func (c *Spectre) ConfigureCosts() spectre.CostStore {
+	if c.Costs.File == "" {
+		return nil
+	}
+	return spectre.NewFileCostStore(c.Costs.File)
+}
+
+func (c *Spectre) ConfigureTxManager(d TxManagerDependencies) (*txmanager.TxManager, error) {
+	return txManagerFactory(d.Context, txmanager.Config{
+		Client:            d.EthereumClient,
+		Signer:            d.Signer,
+		ResendAfterBlocks: c.Transactions.ResendAfterBlocks,
+		FeeBump:           c.Transactions.FeeBump,
+		MaxFee:            d.MaxFee,
+		Interval:          time.Second * time.Duration(c.Transactions.Interval),
+		Logger:            d.Logger,
+	})
+}
+
+// ConfigureChains configures signers, clients and transaction managers for
+// additional chains.
+func (c *Spectre) ConfigureChains(d ChainsDependencies) (map[string]ChainDependencies, error) {
+	chains := make(map[string]ChainDependencies)
+	for name, chain := range c.Chains {
+		sig, err := chain.Ethereum.ConfigureSigner()
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for the %s chain: %w", name, err)
+		}
+		cli, err := chain.Ethereum.ConfigureEthereumClient(sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for the %s chain: %w", name, err)
+		}
+		txm, err := c.ConfigureTxManager(TxManagerDependencies{
+			Context:        d.Context,
+			Signer:         sig,
+			EthereumClient: cli,
+			MaxFee:         chain.Ethereum.Fees.Ceiling(),
+			Logger:         d.Logger.WithField("chain", name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		chains[name] = ChainDependencies{
+			Signer:         sig,
+			EthereumClient: cli,
+			TxManager:      txm,
+		}
+	}
+	return chains, nil
+}
+
+// Addresses returns the addresses of coordinated relayers.
+func (c *Coordination) Addresses() ([]ethereum.Address, error) {
+	var relayers []ethereum.Address
+	for _, addr := range c.Relayers {
+		if !ethereum.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid relayer address: %s", addr)
+		}
+		relayers = append(relayers, ethereum.HexToAddress(addr))
+	}
+	return relayers, nil
+}
+
+// ConfigureCoordinator returns the coordinator or nil if the coordination
+// is disabled.
+func (c *Spectre) ConfigureCoordinator(d CoordinatorDependencies) (*spectre.Coordinator, error) {
+	if len(c.Coordination.Relayers) == 0 {
+		return nil, nil
+	}
+	relayers, err := c.Coordination.Addresses()
+	if err != nil {
+		return nil, err
+	}
+	return coordinatorFactory(d.Context, spectre.CoordinatorConfig{
+		Signer:        d.Signer,
+		Transport:     d.Transport,
+		Relayers:      relayers,
+		SlotDuration:  time.Second * time.Duration(c.Coordination.SlotDuration),
+		FallbackDelay: time.Second * time.Duration(c.Coordination.FallbackDelay),
+		Logger:        d.Logger,
+	})
+}
+
+// ConfigureStatusServer returns the status server or nil if the server is
+// disabled.
+func (c *Spectre) ConfigureStatusServer(d StatusServerDependencies) (*httpapi.Server, error) {
+	if c.Status.Address == "" {
+		return nil, nil
+	}
+	return statusServerFactory(d.Context, httpapi.Config{
+		Spectre: d.Spectre,
+		Address: c.Status.Address,
+		Logger:  d.Logger,
+	})
+}
+
 func (c *Spectre) ConfigureDatastore(d DatastoreDependencies) (datastore.Datastore, error) {
 	cfg := datastoreMemory.Config{
 		Signer:    d.Signer,
@@ -92,8 +377,48 @@ func (c *Spectre) ConfigureDatastore(d DatastoreDependencies) (datastore.Datasto
 		Pairs:     make(map[string]*datastoreMemory.Pair),
 		Logger:    d.Logger,
 	}
-	for name := range c.Medianizers {
-		cfg.Pairs[name] = &datastoreMemory.Pair{Feeds: d.Feeds}
+	for name, pair := range c.Medianizers {
+		cfg.Pairs[pair.assetPair(name)] = &datastoreMemory.Pair{Feeds: d.Feeds}
 	}
 	return datastoreFactory(d.Context, cfg)
 }
+
+// client returns the client used to send poke transactions. If the
+// transaction manager is available, transactions are sent through it.
+func (c ChainDependencies) client() ethereum.Client {
+	if c.TxManager != nil {
+		return c.TxManager
+	}
+	return c.EthereumClient
+}
+
+func (c Medianizer) assetPair(name string) string {
+	if c.Pair != "" {
+		return c.Pair
+	}
+	return name
+}
+
+// dailyBudget returns the daily budget in wei or nil if the budget is
+// unlimited.
+func (c Medianizer) dailyBudget() (*big.Int, error) {
+	if c.DailyBudget < 0 {
+		return nil, fmt.Errorf("daily budget must not be negative")
+	}
+	if c.DailyBudget == 0 {
+		return nil, nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(c.DailyBudget), big.NewFloat(1e18)).Int(nil)
+	return wei, nil
+}
+
+func (c Medianizer) priceSelection() (spectre.PriceSelection, error) {
+	switch c.PriceSelection {
+	case "", FreshestPriceSelection:
+		return spectre.SelectFreshest, nil
+	case ClosestToMedianPriceSelection:
+		return spectre.SelectClosestToMedian, nil
+	default:
+		return 0, fmt.Errorf("unknown price selection strategy: %s", c.PriceSelection)
+	}
+}
@@ -17,17 +17,23 @@ package spectre
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	ethereumConfig "github.com/makerdao/oracle-suite/internal/config/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/datastore"
 	datastoreMemory "github.com/makerdao/oracle-suite/pkg/datastore/memory"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	ethereumMocks "github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/txmanager"
 	"github.com/makerdao/oracle-suite/pkg/log/null"
 	"github.com/makerdao/oracle-suite/pkg/spectre"
+	"github.com/makerdao/oracle-suite/pkg/spectre/httpapi"
+	"github.com/makerdao/oracle-suite/pkg/transport/local"
 )
 
 func TestSpectre_Configure(t *testing.T) {
@@ -46,15 +52,20 @@ func TestSpectre_Configure(t *testing.T) {
 	logger := null.New()
 
 	config := Spectre{
-		Interval: interval,
+		Interval:      interval,
+		PokeMulticall: "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
 		Medianizers: map[string]Medianizer{
 			"AAABBB": {
 				Contract:         "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f",
 				OracleSpread:     0.1,
 				OracleExpiration: 15500,
 				MsgExpiration:    1800,
+				PriceSelection:   "closestToMedian",
+				OSM:              "0x81FE72B5A8d1A857d176C3E7d5Bd2679A9B85763",
+				DailyBudget:      0.5,
 			},
 		},
+		Costs: Costs{File: "costs.json"},
 	}
 
 	spectreFactory = func(ctx context.Context, cfg spectre.Config) (*spectre.Spectre, error) {
@@ -67,7 +78,12 @@ func TestSpectre_Configure(t *testing.T) {
 		assert.Equal(t, secToDuration(config.Medianizers["AAABBB"].OracleExpiration), cfg.Pairs[0].OracleExpiration)
 		assert.Equal(t, secToDuration(config.Medianizers["AAABBB"].MsgExpiration), cfg.Pairs[0].PriceExpiration)
 		assert.Equal(t, config.Medianizers["AAABBB"].OracleSpread, cfg.Pairs[0].OracleSpread)
+		assert.Equal(t, spectre.SelectClosestToMedian, cfg.Pairs[0].PriceSelection)
 		assert.Equal(t, ethereum.HexToAddress(config.Medianizers["AAABBB"].Contract), cfg.Pairs[0].Median.Address())
+		assert.Equal(t, ethereum.HexToAddress(config.Medianizers["AAABBB"].OSM), cfg.Pairs[0].OSM.Address())
+		assert.Equal(t, ethereum.HexToAddress(config.PokeMulticall), cfg.Pairs[0].Multicall.Address())
+		assert.Equal(t, big.NewInt(5e17), cfg.Pairs[0].DailyBudget)
+		assert.IsType(t, &spectre.FileCostStore{}, cfg.Costs)
 		return &spectre.Spectre{}, nil
 	}
 
@@ -83,6 +99,299 @@ func TestSpectre_Configure(t *testing.T) {
 	require.NotNil(t, s)
 }
 
+func TestSpectre_Configure_InvalidPriceSelection(t *testing.T) {
+	config := Spectre{
+		Medianizers: map[string]Medianizer{
+			"AAABBB": {
+				Contract:       "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f",
+				PriceSelection: "unknown",
+			},
+		},
+	}
+
+	_, err := config.ConfigureSpectre(Dependencies{
+		Context:        context.Background(),
+		EthereumClient: &ethereumMocks.Client{},
+		Logger:         null.New(),
+	})
+	assert.Error(t, err)
+}
+
+func TestSpectre_Configure_InvalidDailyBudget(t *testing.T) {
+	config := Spectre{
+		Medianizers: map[string]Medianizer{
+			"AAABBB": {
+				Contract:    "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f",
+				DailyBudget: -1,
+			},
+		},
+	}
+
+	_, err := config.ConfigureSpectre(Dependencies{
+		Context:        context.Background(),
+		EthereumClient: &ethereumMocks.Client{},
+		Logger:         null.New(),
+	})
+	assert.Error(t, err)
+}
+
+func TestSpectre_Configure_Chains(t *testing.T) {
+	prevSpectreFactory := spectreFactory
+	defer func() { spectreFactory = prevSpectreFactory }()
+
+	l2TxManager := &txmanager.TxManager{}
+	config := Spectre{
+		PokeMulticall: "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
+		Medianizers: map[string]Medianizer{
+			"AAABBB": {
+				Contract: "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f",
+			},
+			"AAABBB/L2": {
+				Pair:     "AAABBB",
+				Chain:    "l2",
+				Contract: "0x81FE72B5A8d1A857d176C3E7d5Bd2679A9B85763",
+			},
+		},
+		Chains: map[string]Chain{
+			"l2": {PokeMulticall: "0x2DC0E2aa608532Da689e89e237dF582B783E552C"},
+		},
+	}
+
+	spectreFactory = func(ctx context.Context, cfg spectre.Config) (*spectre.Spectre, error) {
+		pairs := map[string]*spectre.Pair{}
+		for _, p := range cfg.Pairs {
+			pairs[p.Name] = p
+		}
+		require.Len(t, pairs, 2)
+
+		assert.Equal(t, "AAABBB", pairs["AAABBB"].AssetPair)
+		assert.Nil(t, pairs["AAABBB"].TxManager)
+		assert.Equal(t, ethereum.HexToAddress(config.PokeMulticall), pairs["AAABBB"].Multicall.Address())
+
+		assert.Equal(t, "AAABBB", pairs["AAABBB/L2"].AssetPair)
+		assert.Equal(t, l2TxManager, pairs["AAABBB/L2"].TxManager)
+		assert.Equal(t, ethereum.HexToAddress(config.Chains["l2"].PokeMulticall), pairs["AAABBB/L2"].Multicall.Address())
+		assert.Equal(t, ethereum.HexToAddress(config.Medianizers["AAABBB/L2"].Contract), pairs["AAABBB/L2"].Median.Address())
+		return &spectre.Spectre{}, nil
+	}
+
+	s, err := config.ConfigureSpectre(Dependencies{
+		Context:        context.Background(),
+		EthereumClient: &ethereumMocks.Client{},
+		Chains: map[string]ChainDependencies{
+			"l2": {EthereumClient: &ethereumMocks.Client{}, TxManager: l2TxManager},
+		},
+		Logger: null.New(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, s)
+}
+
+func TestSpectre_Configure_UnknownChain(t *testing.T) {
+	config := Spectre{
+		Medianizers: map[string]Medianizer{
+			"AAABBB": {
+				Contract: "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f",
+				Chain:    "unknown",
+			},
+		},
+	}
+
+	_, err := config.ConfigureSpectre(Dependencies{
+		Context:        context.Background(),
+		EthereumClient: &ethereumMocks.Client{},
+		Logger:         null.New(),
+	})
+	assert.Error(t, err)
+}
+
+func TestSpectre_ConfigureChains_InvalidConfig(t *testing.T) {
+	config := Spectre{
+		Chains: map[string]Chain{
+			"l2": {Ethereum: ethereumConfig.Ethereum{}},
+		},
+	}
+
+	_, err := config.ConfigureChains(ChainsDependencies{
+		Context: context.Background(),
+		Logger:  null.New(),
+	})
+	assert.Error(t, err)
+}
+
+func TestSpectre_ConfigureDatastore(t *testing.T) {
+	prevDatastoreFactory := datastoreFactory
+	defer func() { datastoreFactory = prevDatastoreFactory }()
+
+	config := Spectre{
+		Medianizers: map[string]Medianizer{
+			"AAABBB":    {},
+			"AAABBB/L2": {Pair: "AAABBB"},
+			"XXXYYY":    {},
+		},
+	}
+
+	datastoreFactory = func(ctx context.Context, cfg datastoreMemory.Config) (datastore.Datastore, error) {
+		assert.Len(t, cfg.Pairs, 2)
+		assert.Contains(t, cfg.Pairs, "AAABBB")
+		assert.Contains(t, cfg.Pairs, "XXXYYY")
+		return &datastoreMemory.Datastore{}, nil
+	}
+
+	_, err := config.ConfigureDatastore(DatastoreDependencies{
+		Context: context.Background(),
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+}
+
+func TestSpectre_ConfigureTxManager(t *testing.T) {
+	prevTxManagerFactory := txManagerFactory
+	defer func() { txManagerFactory = prevTxManagerFactory }()
+
+	signer := &ethereumMocks.Signer{}
+	ethClient := &ethereumMocks.Client{}
+	logger := null.New()
+
+	config := Spectre{
+		Transactions: Transactions{
+			ResendAfterBlocks: 3,
+			FeeBump:           20,
+			Interval:          10,
+		},
+	}
+
+	txManagerFactory = func(ctx context.Context, cfg txmanager.Config) (*txmanager.TxManager, error) {
+		assert.NotNil(t, ctx)
+		assert.Equal(t, ethClient, cfg.Client)
+		assert.Equal(t, signer, cfg.Signer)
+		assert.Equal(t, uint64(3), cfg.ResendAfterBlocks)
+		assert.Equal(t, float64(20), cfg.FeeBump)
+		assert.Equal(t, big.NewInt(100), cfg.MaxFee)
+		assert.Equal(t, secToDuration(10), cfg.Interval)
+		assert.Equal(t, logger, cfg.Logger)
+		return &txmanager.TxManager{}, nil
+	}
+
+	m, err := config.ConfigureTxManager(TxManagerDependencies{
+		Context:        context.Background(),
+		Signer:         signer,
+		EthereumClient: ethClient,
+		MaxFee:         big.NewInt(100),
+		Logger:         logger,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, m)
+}
+
+func TestSpectre_ConfigureCoordinator(t *testing.T) {
+	prevCoordinatorFactory := coordinatorFactory
+	defer func() { coordinatorFactory = prevCoordinatorFactory }()
+
+	signer := &ethereumMocks.Signer{}
+	tra := &local.Local{}
+	logger := null.New()
+
+	config := Spectre{
+		Coordination: Coordination{
+			Relayers: []string{
+				"0x2d800d93b065ce011af83f316cef9f0d005b0aa4",
+				"0xe3ced0f62f7eb2856d37bed128d2b195712d2644",
+			},
+			SlotDuration:  60,
+			FallbackDelay: 15,
+		},
+	}
+
+	coordinatorFactory = func(ctx context.Context, cfg spectre.CoordinatorConfig) (*spectre.Coordinator, error) {
+		assert.NotNil(t, ctx)
+		assert.Equal(t, signer, cfg.Signer)
+		assert.Equal(t, tra, cfg.Transport)
+		assert.Equal(t, []ethereum.Address{
+			ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4"),
+			ethereum.HexToAddress("0xe3ced0f62f7eb2856d37bed128d2b195712d2644"),
+		}, cfg.Relayers)
+		assert.Equal(t, secToDuration(60), cfg.SlotDuration)
+		assert.Equal(t, secToDuration(15), cfg.FallbackDelay)
+		assert.Equal(t, logger, cfg.Logger)
+		return &spectre.Coordinator{}, nil
+	}
+
+	c, err := config.ConfigureCoordinator(CoordinatorDependencies{
+		Context:   context.Background(),
+		Signer:    signer,
+		Transport: tra,
+		Logger:    logger,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, c)
+}
+
+func TestSpectre_ConfigureCoordinator_Disabled(t *testing.T) {
+	config := Spectre{}
+
+	c, err := config.ConfigureCoordinator(CoordinatorDependencies{
+		Context: context.Background(),
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, c)
+}
+
+func TestSpectre_ConfigureCoordinator_InvalidAddress(t *testing.T) {
+	config := Spectre{
+		Coordination: Coordination{Relayers: []string{"invalid"}},
+	}
+
+	_, err := config.ConfigureCoordinator(CoordinatorDependencies{
+		Context: context.Background(),
+		Logger:  null.New(),
+	})
+	assert.Error(t, err)
+}
+
+func TestSpectre_ConfigureStatusServer(t *testing.T) {
+	prevStatusServerFactory := statusServerFactory
+	defer func() { statusServerFactory = prevStatusServerFactory }()
+
+	spe := &spectre.Spectre{}
+	logger := null.New()
+
+	config := Spectre{Status: Status{Address: "127.0.0.1:9101"}}
+
+	statusServerFactory = func(ctx context.Context, cfg httpapi.Config) (*httpapi.Server, error) {
+		assert.NotNil(t, ctx)
+		assert.Equal(t, spe, cfg.Spectre)
+		assert.Equal(t, "127.0.0.1:9101", cfg.Address)
+		assert.Equal(t, logger, cfg.Logger)
+		return &httpapi.Server{}, nil
+	}
+
+	s, err := config.ConfigureStatusServer(StatusServerDependencies{
+		Context: context.Background(),
+		Spectre: spe,
+		Logger:  logger,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, s)
+}
+
+func TestSpectre_ConfigureStatusServer_Disabled(t *testing.T) {
+	config := Spectre{}
+
+	s, err := config.ConfigureStatusServer(StatusServerDependencies{
+		Context: context.Background(),
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestSpectre_ConfigureCosts(t *testing.T) {
+	assert.Nil(t, (&Spectre{}).ConfigureCosts())
+	assert.IsType(t, &spectre.FileCostStore{}, (&Spectre{Costs: Costs{File: "costs.json"}}).ConfigureCosts())
+}
+
 func secToDuration(s int64) time.Duration {
 	return time.Duration(s) * time.Second
 }
@@ -17,6 +17,9 @@ package spire
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/makerdao/oracle-suite/pkg/datastore"
 	datastoreMemory "github.com/makerdao/oracle-suite/pkg/datastore/memory"
@@ -43,6 +46,12 @@ var datastoreFactory = func(ctx context.Context, cfg datastoreMemory.Config) (da
 type Spire struct {
 	RPC   RPC      `json:"rpc"`
 	Pairs []string `json:"pairs"`
+	// VerifyStarkSignatures enables verification of StarkEx signatures of
+	// received prices.
+	VerifyStarkSignatures bool `json:"verifyStarkSignatures"`
+	// StarkKeys maps feeder addresses to their stark public keys, as hex
+	// numbers. Stark signatures are verified only against these keys.
+	StarkKeys map[string]string `json:"starkKeys"`
 }
 
 type RPC struct {
@@ -95,14 +104,35 @@ func (c *Spire) ConfigureClient(d ClientDependencies) (*spire.Client, error) {
 }
 
 func (c *Spire) ConfigureDatastore(d DatastoreDependencies) (datastore.Datastore, error) {
+	starkKeys, err := c.starkKeys()
+	if err != nil {
+		return nil, err
+	}
 	cfg := datastoreMemory.Config{
-		Signer:    d.Signer,
-		Transport: d.Transport,
-		Pairs:     make(map[string]*datastoreMemory.Pair),
-		Logger:    d.Logger,
+		Signer:                d.Signer,
+		VerifyStarkSignatures: c.VerifyStarkSignatures,
+		StarkKeys:             starkKeys,
+		Transport:             d.Transport,
+		Pairs:                 make(map[string]*datastoreMemory.Pair),
+		Logger:                d.Logger,
 	}
 	for _, name := range c.Pairs {
 		cfg.Pairs[name] = &datastoreMemory.Pair{Feeds: d.Feeds}
 	}
 	return datastoreFactory(d.Context, cfg)
 }
+
+func (c *Spire) starkKeys() (map[ethereum.Address]*big.Int, error) {
+	keys := make(map[ethereum.Address]*big.Int, len(c.StarkKeys))
+	for addr, key := range c.StarkKeys {
+		if !ethereum.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid feeder address in stark keys: %s", addr)
+		}
+		k, ok := new(big.Int).SetString(strings.TrimPrefix(key, "0x"), 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid stark public key of the %s feeder: %s", addr, key)
+		}
+		keys[ethereum.HexToAddress(addr)] = k
+	}
+	return keys, nil
+}
@@ -17,11 +17,13 @@ package spire
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/makerdao/oracle-suite/pkg/datastore"
 	datastoreMemory "github.com/makerdao/oracle-suite/pkg/datastore/memory"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	ethereumMocks "github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
@@ -96,3 +98,39 @@ func TestSpire_ConfigureClient(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, c)
 }
+
+func TestSpire_ConfigureDatastore_StarkKeys(t *testing.T) {
+	prevDatastoreFactory := datastoreFactory
+	defer func() { datastoreFactory = prevDatastoreFactory }()
+
+	config := Spire{
+		Pairs:                 []string{"AAABBB"},
+		VerifyStarkSignatures: true,
+		StarkKeys: map[string]string{
+			"0x2d800d93b065ce011af83f316cef9f0d005b0aa4": "0x77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43",
+		},
+	}
+
+	datastoreFactory = func(ctx context.Context, cfg datastoreMemory.Config) (datastore.Datastore, error) {
+		key, _ := new(big.Int).SetString("77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43", 16)
+		assert.True(t, cfg.VerifyStarkSignatures)
+		assert.Equal(t, map[ethereum.Address]*big.Int{
+			ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4"): key,
+		}, cfg.StarkKeys)
+		return &datastoreMemory.Datastore{}, nil
+	}
+
+	d, err := config.ConfigureDatastore(DatastoreDependencies{
+		Context: context.Background(),
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, d)
+
+	config.StarkKeys = map[string]string{"0x2d800d93b065ce011af83f316cef9f0d005b0aa4": "xyz"}
+	_, err = config.ConfigureDatastore(DatastoreDependencies{
+		Context: context.Background(),
+		Logger:  null.New(),
+	})
+	assert.Error(t, err)
+}
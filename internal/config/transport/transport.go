@@ -29,6 +29,7 @@ import (
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/log"
 	"github.com/makerdao/oracle-suite/pkg/transport"
+	"github.com/makerdao/oracle-suite/pkg/transport/fanout"
 	"github.com/makerdao/oracle-suite/pkg/transport/messages"
 	"github.com/makerdao/oracle-suite/pkg/transport/p2p"
 	"github.com/makerdao/oracle-suite/pkg/transport/p2p/crypto/ethkey"
@@ -40,6 +41,10 @@ var p2pTransportFactory = func(ctx context.Context, cfg p2p.Config) (transport.T
 
 type Transport struct {
 	P2P P2P `json:"p2p"`
+	// SecondaryP2P is a list of additional P2P nodes, e.g. connected to
+	// a new network during a migration. Messages are broadcast to all
+	// networks and received messages are merged.
+	SecondaryP2P []P2P `json:"secondaryP2P"`
 }
 
 type P2P struct {
@@ -56,6 +61,9 @@ type Dependencies struct {
 	Signer  ethereum.Signer
 	Feeds   []ethereum.Address
 	Logger  log.Logger
+	// Relayers is a list of relayers allowed to send relayer intents. If
+	// empty, the node does not subscribe to relayer intents.
+	Relayers []ethereum.Address
 }
 
 type BootstrapDependencies struct {
@@ -64,21 +72,54 @@ type BootstrapDependencies struct {
 }
 
 func (c *Transport) Configure(d Dependencies) (transport.Transport, error) {
+	if len(c.SecondaryP2P) == 0 {
+		return c.P2P.configure(d)
+	}
+	// All transports share a context owned by the fan-out transport, so it
+	// can stop them if one of them fails to start:
+	ctx, ctxCancel := context.WithCancel(d.Context)
+	d.Context = ctx
+	var ts []transport.Transport
+	for _, s := range append([]P2P{c.P2P}, c.SecondaryP2P...) {
+		p, err := s.configure(d)
+		if err != nil {
+			ctxCancel()
+			return nil, err
+		}
+		ts = append(ts, p)
+	}
+	f, err := fanout.New(ctx, ctxCancel, d.Logger, ts...)
+	if err != nil {
+		ctxCancel()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (c *P2P) configure(d Dependencies) (transport.Transport, error) {
 	peerPrivKey, err := c.generatePrivKey()
 	if err != nil {
 		return nil, err
 	}
+	topics := map[string]transport.Message{
+		messages.PriceMessageName:       (*messages.Price)(nil),
+		messages.PriceBundleMessageName: (*messages.PriceBundle)(nil),
+	}
+	if len(d.Relayers) > 0 {
+		topics[messages.RelayerIntentMessageName] = (*messages.RelayerIntent)(nil)
+	}
 	cfg := p2p.Config{
 		Mode:             p2p.ClientMode,
 		PeerPrivKey:      peerPrivKey,
-		Topics:           map[string]transport.Message{messages.PriceMessageName: (*messages.Price)(nil)},
+		Topics:           topics,
 		MessagePrivKey:   ethkey.NewPrivKey(d.Signer),
-		ListenAddrs:      c.P2P.ListenAddrs,
-		BootstrapAddrs:   c.P2P.BootstrapAddrs,
-		DirectPeersAddrs: c.P2P.DirectPeersAddrs,
-		BlockedAddrs:     c.P2P.BlockedAddrs,
+		ListenAddrs:      c.ListenAddrs,
+		BootstrapAddrs:   c.BootstrapAddrs,
+		DirectPeersAddrs: c.DirectPeersAddrs,
+		BlockedAddrs:     c.BlockedAddrs,
 		FeedersAddrs:     d.Feeds,
-		Discovery:        !c.P2P.DisableDiscovery,
+		RelayersAddrs:    d.Relayers,
+		Discovery:        !c.DisableDiscovery,
 		Signer:           d.Signer,
 		Logger:           d.Logger,
 		AppName:          "spire",
@@ -92,7 +133,7 @@ func (c *Transport) Configure(d Dependencies) (transport.Transport, error) {
 }
 
 func (c *Transport) ConfigureP2PBoostrap(d BootstrapDependencies) (transport.Transport, error) {
-	peerPrivKey, err := c.generatePrivKey()
+	peerPrivKey, err := c.P2P.generatePrivKey()
 	if err != nil {
 		return nil, err
 	}
@@ -114,10 +155,10 @@ func (c *Transport) ConfigureP2PBoostrap(d BootstrapDependencies) (transport.Tra
 	return p, nil
 }
 
-func (c *Transport) generatePrivKey() (crypto.PrivKey, error) {
+func (c *P2P) generatePrivKey() (crypto.PrivKey, error) {
 	seedReader := rand.Reader
-	if len(c.P2P.PrivKeySeed) != 0 {
-		seed, err := hex.DecodeString(c.P2P.PrivKeySeed)
+	if len(c.PrivKeySeed) != 0 {
+		seed, err := hex.DecodeString(c.PrivKeySeed)
 		if err != nil {
 			return nil, fmt.Errorf("invalid privKeySeed value, failed to decode hex data: %w", err)
 		}
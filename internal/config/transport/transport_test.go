@@ -26,6 +26,7 @@ import (
 	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
 	"github.com/makerdao/oracle-suite/pkg/log/null"
 	"github.com/makerdao/oracle-suite/pkg/transport"
+	"github.com/makerdao/oracle-suite/pkg/transport/fanout"
 	"github.com/makerdao/oracle-suite/pkg/transport/local"
 	"github.com/makerdao/oracle-suite/pkg/transport/messages"
 	"github.com/makerdao/oracle-suite/pkg/transport/p2p"
@@ -57,7 +58,10 @@ func TestTransport_P2P_EmptyConfig(t *testing.T) {
 		assert.Len(t, cfg.BootstrapAddrs, 0)
 		assert.Len(t, cfg.DirectPeersAddrs, 0)
 		assert.Len(t, cfg.BlockedAddrs, 0)
-		assert.Equal(t, map[string]transport.Message{messages.PriceMessageName: (*messages.Price)(nil)}, cfg.Topics)
+		assert.Equal(t, map[string]transport.Message{
+			messages.PriceMessageName:       (*messages.Price)(nil),
+			messages.PriceBundleMessageName: (*messages.PriceBundle)(nil),
+		}, cfg.Topics)
 		assert.Equal(t, true, cfg.Discovery)
 		assert.Equal(t, "spire", cfg.AppName)
 		assert.Equal(t, feeds, cfg.FeedersAddrs)
@@ -82,6 +86,7 @@ func TestTransport_P2P_CustomValues(t *testing.T) {
 	defer func() { p2pTransportFactory = prevP2PTransportFactory }()
 
 	feeds := []ethereum.Address{ethereum.HexToAddress("0x07a35a1d4b751a818d93aa38e615c0df23064881")}
+	relayers := []ethereum.Address{ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")}
 	signer := &mocks.Signer{}
 	logger := null.New()
 	privKeySeed := "d382e2b16d8a2e770dd8e0b65554a2ce7a072ac67d4ca6f34052771dfdcdac07"
@@ -108,10 +113,15 @@ func TestTransport_P2P_CustomValues(t *testing.T) {
 		assert.Equal(t, bootstrapAddrs, cfg.BootstrapAddrs)
 		assert.Equal(t, directPeersAddrs, cfg.DirectPeersAddrs)
 		assert.Equal(t, blockedAddrs, cfg.BlockedAddrs)
-		assert.Equal(t, map[string]transport.Message{messages.PriceMessageName: (*messages.Price)(nil)}, cfg.Topics)
+		assert.Equal(t, map[string]transport.Message{
+			messages.PriceMessageName:         (*messages.Price)(nil),
+			messages.PriceBundleMessageName:   (*messages.PriceBundle)(nil),
+			messages.RelayerIntentMessageName: (*messages.RelayerIntent)(nil),
+		}, cfg.Topics)
 		assert.Equal(t, false, cfg.Discovery)
 		assert.Equal(t, "spire", cfg.AppName)
 		assert.Equal(t, feeds, cfg.FeedersAddrs)
+		assert.Equal(t, relayers, cfg.RelayersAddrs)
 		assert.Same(t, signer, cfg.Signer)
 		assert.Same(t, logger, cfg.Logger)
 
@@ -119,10 +129,11 @@ func TestTransport_P2P_CustomValues(t *testing.T) {
 	}
 
 	tra, err := config.Configure(Dependencies{
-		Context: context.Background(),
-		Signer:  signer,
-		Feeds:   feeds,
-		Logger:  logger,
+		Context:  context.Background(),
+		Signer:   signer,
+		Feeds:    feeds,
+		Logger:   logger,
+		Relayers: relayers,
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, tra)
@@ -160,3 +171,28 @@ func TestTransport_P2P_InvalidSeed(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestTransport_P2P_Secondary(t *testing.T) {
+	prevP2PTransportFactory := p2pTransportFactory
+	defer func() { p2pTransportFactory = prevP2PTransportFactory }()
+
+	config := Transport{
+		P2P:          P2P{ListenAddrs: []string{"/ip4/0.0.0.0/tcp/8000"}},
+		SecondaryP2P: []P2P{{ListenAddrs: []string{"/ip4/0.0.0.0/tcp/9000"}}},
+	}
+
+	var listenAddrs []string
+	p2pTransportFactory = func(ctx context.Context, cfg p2p.Config) (transport.Transport, error) {
+		listenAddrs = append(listenAddrs, cfg.ListenAddrs...)
+		return local.New(context.Background(), 0, nil), nil
+	}
+
+	tra, err := config.Configure(Dependencies{
+		Context: context.Background(),
+		Signer:  &mocks.Signer{},
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &fanout.FanOut{}, tra)
+	assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/8000", "/ip4/0.0.0.0/tcp/9000"}, listenAddrs)
+}
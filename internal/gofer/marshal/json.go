@@ -48,6 +48,8 @@ func (j *json) Write(writer io.Writer, item interface{}) error {
 		i = j.handlePrice(typedItem)
 	case *gofer.Model:
 		i = j.handleModel(typedItem)
+	case *gofer.PriceSeries:
+		i = j.handlePriceSeries(typedItem)
 	case error:
 		i = j.handleError(typedItem)
 	default:
@@ -106,6 +108,19 @@ func (*json) handleModel(node *gofer.Model) interface{} {
 	return node.Pair.String()
 }
 
+func (*json) handlePriceSeries(series *gofer.PriceSeries) interface{} {
+	prices := []jsonPrice{}
+	for _, p := range series.Prices {
+		prices = append(prices, jsonPriceFromGoferPrice(p))
+	}
+	return jsonPriceSeries{
+		Origin: series.Origin,
+		Base:   series.Pair.Base,
+		Quote:  series.Pair.Quote,
+		Prices: prices,
+	}
+}
+
 func (*json) handleError(err error) interface{} {
 	return struct {
 		Error string `json:"error"`
@@ -126,6 +141,13 @@ type jsonPrice struct {
 	Error      string            `json:"error,omitempty"`
 }
 
+type jsonPriceSeries struct {
+	Origin string      `json:"origin"`
+	Base   string      `json:"base"`
+	Quote  string      `json:"quote"`
+	Prices []jsonPrice `json:"prices"`
+}
+
 func jsonPriceFromGoferPrice(t *gofer.Price) jsonPrice {
 	var prices []jsonPrice
 	for _, c := range t.Prices {
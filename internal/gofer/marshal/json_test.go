@@ -18,6 +18,7 @@ package marshal
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -193,3 +194,39 @@ func TestJSON_Prices(t *testing.T) {
 
 	assert.JSONEq(t, expected, b.String())
 }
+
+func TestJSON_PriceSeries(t *testing.T) {
+	var err error
+	b := &bytes.Buffer{}
+	m := newJSON(false)
+
+	ab := gofer.Pair{Base: "A", Quote: "B"}
+	err = m.Write(b, &gofer.PriceSeries{
+		Origin: "a",
+		Pair:   ab,
+		Prices: []*gofer.Price{{Type: "origin", Pair: ab, Price: 10, Time: time.Unix(10, 0)}},
+	})
+	assert.NoError(t, err)
+
+	err = m.Write(b, &gofer.PriceSeries{Origin: "b", Pair: ab})
+	assert.NoError(t, err)
+
+	err = m.Flush()
+	assert.NoError(t, err)
+
+	expected := `
+		[
+		   {
+			  "origin":"a",
+			  "base":"A",
+			  "quote":"B",
+			  "prices":[
+				 {"type":"origin","base":"A","quote":"B","price":10,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:10Z"}
+			  ]
+		   },
+		   {"origin":"b","base":"A","quote":"B","prices":[]}
+		]
+	`
+
+	assert.JSONEq(t, expected, b.String())
+}
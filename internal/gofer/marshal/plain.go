@@ -16,9 +16,11 @@
 package marshal
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 )
@@ -44,6 +46,8 @@ func (p *plain) Write(writer io.Writer, item interface{}) error {
 		i = p.handlePrice(typedItem)
 	case *gofer.Model:
 		i = p.handleModel(typedItem)
+	case *gofer.PriceSeries:
+		i = p.handlePriceSeries(typedItem)
 	case error:
 		i = []byte(fmt.Sprintf("Error: %s", typedItem.Error()))
 	default:
@@ -80,3 +84,22 @@ func (*plain) handlePrice(price *gofer.Price) []byte {
 func (*plain) handleModel(node *gofer.Model) []byte {
 	return []byte(node.Pair.String())
 }
+
+func (*plain) handlePriceSeries(series *gofer.PriceSeries) []byte {
+	if len(series.Prices) == 0 {
+		return []byte(fmt.Sprintf("%s %s - no prices", series.Pair, series.Origin))
+	}
+	buf := bytes.Buffer{}
+	for i, price := range series.Prices {
+		ts := price.Time.In(time.UTC).Format(time.RFC3339)
+		if price.Error != "" {
+			buf.WriteString(fmt.Sprintf("%s %s %s - %s", series.Pair, series.Origin, ts, strings.TrimSpace(price.Error)))
+		} else {
+			buf.WriteString(fmt.Sprintf("%s %s %s %f", series.Pair, series.Origin, ts, price.Price))
+		}
+		if i != len(series.Prices)-1 {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
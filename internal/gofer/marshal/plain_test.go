@@ -18,6 +18,7 @@ package marshal
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -78,3 +79,34 @@ C/D - something
 
 	assert.Equal(t, expected, b.String())
 }
+
+func TestPlain_PriceSeries(t *testing.T) {
+	var err error
+	b := &bytes.Buffer{}
+	m := newPlain()
+
+	ab := gofer.Pair{Base: "A", Quote: "B"}
+	err = m.Write(b, &gofer.PriceSeries{
+		Origin: "a",
+		Pair:   ab,
+		Prices: []*gofer.Price{
+			{Type: "origin", Pair: ab, Price: 10, Time: time.Unix(10, 0)},
+			{Type: "origin", Pair: ab, Time: time.Unix(20, 0), Error: "something"},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = m.Write(b, &gofer.PriceSeries{Origin: "b", Pair: ab})
+	assert.NoError(t, err)
+
+	err = m.Flush()
+	assert.NoError(t, err)
+
+	expected := `
+A/B a 1970-01-01T00:00:10Z 10.000000
+A/B a 1970-01-01T00:00:20Z - something
+A/B b - no prices
+`[1:]
+
+	assert.Equal(t, expected, b.String())
+}
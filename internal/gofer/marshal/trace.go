@@ -48,6 +48,8 @@ func (t *trace) Write(writer io.Writer, item interface{}) error {
 		i = t.handlePrice(typedItem)
 	case *gofer.Model:
 		i = t.handleModel(typedItem)
+	case *gofer.PriceSeries:
+		i = t.handlePriceSeries(typedItem)
 	case error:
 		i = []byte(fmt.Sprintf("Error: %s", typedItem.Error()))
 	default:
@@ -133,6 +135,34 @@ func (t *trace) handleModel(node *gofer.Model) []byte {
 	return buf.Bytes()
 }
 
+func (*trace) handlePriceSeries(series *gofer.PriceSeries) []byte {
+	var prices []interface{}
+	for _, p := range series.Prices {
+		prices = append(prices, p)
+	}
+	tree := renderTree(func(node interface{}) ([]byte, []interface{}) {
+		p := node.(*gofer.Price)
+		var pErr error
+		if p.Error != "" {
+			pErr = errors.New(p.Error)
+		}
+		s := renderNode(
+			p.Type,
+			[]param{
+				{key: "price", value: p.Price},
+				{key: "timestamp", value: p.Time.In(time.UTC).Format(time.RFC3339Nano)},
+			},
+			pErr,
+		)
+		return s, nil
+	}, prices, 0)
+
+	buf := bytes.Buffer{}
+	buf.Write([]byte(fmt.Sprintf("History for %s from %s:\n", series.Pair, series.Origin)))
+	buf.Write(tree)
+	return buf.Bytes()
+}
+
 // param is used to work with lists of sorted key/value pairs.
 type param struct {
 	key   string
@@ -17,6 +17,8 @@ package httpserver
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"time"
 )
@@ -34,10 +36,12 @@ func (m MiddlewareFunc) Handle(h http.Handler) http.Handler {
 // HTTPServer allows using middlewares with http.Server and allow controlling
 // server lifecycle using context.
 type HTTPServer struct {
-	ctx    context.Context
-	doneCh chan error
+	ctx        context.Context
+	doneCh     chan error
+	serveErrCh chan error
 
 	server         *http.Server
+	listener       net.Listener
 	handler        http.Handler
 	wrappedHandler http.Handler
 	middlewares    []Middleware
@@ -46,8 +50,10 @@ type HTTPServer struct {
 // New creates a new HTTPServer instance.
 func New(ctx context.Context, srv *http.Server) *HTTPServer {
 	s := &HTTPServer{
-		ctx:    ctx,
-		server: srv,
+		ctx:        ctx,
+		doneCh:     make(chan error, 1),
+		serveErrCh: make(chan error, 1),
+		server:     srv,
 	}
 	s.handler = srv.Handler
 	srv.Handler = http.HandlerFunc(s.ServeHTTP)
@@ -80,25 +86,54 @@ func (s *HTTPServer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	s.wrappedHandler.ServeHTTP(rw, r)
 }
 
-// ListenAndServe calls function with the same name on the wrapped server.
+// ListenAndServe starts listening on the address of the wrapped server and
+// serves requests in a separate goroutine. The server is shut down when
+// the context is cancelled. If the server stops serving for any other reason,
+// the error is returned by the Wait method.
 func (s *HTTPServer) ListenAndServe() error {
-	err := s.server.ListenAndServe()
+	addr := s.server.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
 	if err != nil {
 		return err
 	}
+	s.listener = ln
+	go s.serve(ln)
 	go s.contextCancelHandler()
 	return nil
 }
 
+// Addr returns the listener's network address. It returns nil if the server
+// is not started.
+func (s *HTTPServer) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
 // Wait waits until server is closed.
 func (s *HTTPServer) Wait() error {
 	return <-s.doneCh
 }
 
+// serve serves requests until the server is closed.
+func (s *HTTPServer) serve(ln net.Listener) {
+	if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.serveErrCh <- err
+	}
+}
+
 // contextCancelHandler handles context cancellation.
 func (s *HTTPServer) contextCancelHandler() {
-	<-s.ctx.Done()
-	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Second)
-	defer ctxCancel()
-	s.doneCh <- s.server.Shutdown(ctx)
+	select {
+	case <-s.ctx.Done():
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Second)
+		defer ctxCancel()
+		s.doneCh <- s.server.Shutdown(ctx)
+	case err := <-s.serveErrCh:
+		s.doneCh <- err
+	}
 }
@@ -17,6 +17,7 @@ package httpserver
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -79,3 +80,37 @@ func TestServer_UseMustPanicAfterServerHTTP(t *testing.T) {
 
 	assert.NotNil(t, panicVal)
 }
+
+func TestServer_ListenAndServe(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	srv := New(ctx, &http.Server{
+		Addr: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("response"))
+		}),
+	})
+	assert.Nil(t, srv.Addr())
+	assert.NoError(t, srv.ListenAndServe())
+
+	res, err := http.Get("http://" + srv.Addr().String())
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, "response", string(body))
+
+	ctxCancel()
+	assert.NoError(t, srv.Wait())
+}
+
+func TestServer_ServeError(t *testing.T) {
+	srv := New(context.Background(), &http.Server{
+		Addr:    "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}),
+	})
+	assert.NoError(t, srv.ListenAndServe())
+
+	// Closing the listener stops the server without cancelling the context:
+	srv.listener.Close()
+	assert.Error(t, srv.Wait())
+}
@@ -54,6 +54,13 @@ func (r *recorder) WriteHeader(code int) {
 	r.rw.WriteHeader(code)
 }
 
+// Flush implements the http.Flusher interface.
+func (r *recorder) Flush() {
+	if f, ok := r.rw.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func readRequest(r *http.Request) []byte {
 	b, _ := io.ReadAll(r.Body)
 	r.Body = io.NopCloser(bytes.NewReader(b))
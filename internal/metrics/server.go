@@ -0,0 +1,95 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/makerdao/oracle-suite/internal/httpserver"
+	"github.com/makerdao/oracle-suite/internal/httpserver/middleware"
+	"github.com/makerdao/oracle-suite/pkg/log"
+)
+
+const LoggerTag = "METRICS"
+
+// Server exposes metrics registered in the default Prometheus registry
+// on the /metrics endpoint.
+type Server struct {
+	ctx    context.Context
+	doneCh chan struct{}
+
+	srv *httpserver.HTTPServer
+	log log.Logger
+}
+
+// NewServer returns a new Server instance which will listen on the
+// given address.
+func NewServer(ctx context.Context, address string, logger log.Logger) (*Server, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	s := &Server{
+		ctx:    ctx,
+		doneCh: make(chan struct{}),
+		srv:    httpserver.New(ctx, &http.Server{Addr: address, Handler: mux}),
+		log:    logger.WithField("tag", LoggerTag),
+	}
+	s.srv.Use(&middleware.Recover{
+		Recover: func(err interface{}) {
+			s.log.WithField("panic", fmt.Sprintf("%s", err)).Error("Server handler crashed")
+		},
+	})
+	return s, nil
+}
+
+// Start starts the HTTP server.
+func (s *Server) Start() error {
+	s.log.Infof("Starting")
+	if err := s.srv.ListenAndServe(); err != nil {
+		return err
+	}
+	go s.contextCancelHandler()
+	return nil
+}
+
+// Wait waits until the server's context is cancelled.
+func (s *Server) Wait() {
+	<-s.doneCh
+}
+
+// Addr returns the server's network address. It returns nil if the server
+// is not started.
+func (s *Server) Addr() net.Addr {
+	return s.srv.Addr()
+}
+
+func (s *Server) contextCancelHandler() {
+	defer func() { close(s.doneCh) }()
+	defer s.log.Info("Stopped")
+	<-s.ctx.Done()
+
+	if err := s.srv.Wait(); err != nil {
+		s.log.WithError(err).Error("Unable to close HTTP server")
+	}
+}
@@ -0,0 +1,55 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+func TestServer(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	promauto.NewCounter(prometheus.CounterOpts{
+		Name: "metrics_server_test_total",
+		Help: "Test counter.",
+	}).Inc()
+
+	s, err := NewServer(ctx, "127.0.0.1:0", null.New())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+
+	res, err := http.Get("http://" + s.Addr().String() + "/metrics")
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(body), "metrics_server_test_total 1")
+
+	ctxCancel()
+	s.Wait()
+}
@@ -0,0 +1,90 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"github.com/libp2p/go-libp2p-core/network"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/makerdao/oracle-suite/pkg/transport"
+)
+
+var (
+	peersMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "transport",
+		Name:      "peers",
+		Help:      "Number of connected peers.",
+	})
+	messagesPublishedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "transport",
+		Name:      "messages_published_total",
+		Help:      "Number of published messages.",
+	}, []string{"topic"})
+	messagesReceivedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "transport",
+		Name:      "messages_received_total",
+		Help:      "Number of received messages.",
+	}, []string{"topic", "result"})
+	messagesBrokenMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "transport",
+		Name:      "messages_broken_total",
+		Help:      "Number of received messages which could not be unmarshalled.",
+	}, []string{"topic"})
+)
+
+// Metrics exports the number of connected peers and the number of published
+// and received messages as Prometheus metrics.
+func Metrics() Options {
+	return func(n *Node) error {
+		update := func(net network.Network, _ network.Conn) {
+			peersMetric.Set(float64(len(net.Peers())))
+		}
+		n.AddNotifee(&network.NotifyBundle{
+			ConnectedF:    update,
+			DisconnectedF: update,
+		})
+		n.AddMessageHandler(&metricsHandler{})
+		return nil
+	}
+}
+
+type metricsHandler struct{}
+
+func (m *metricsHandler) Published(topic string, _ []byte, _ transport.Message) {
+	messagesPublishedMetric.WithLabelValues(topic).Inc()
+}
+
+func (m *metricsHandler) Received(topic string, _ *pubsub.Message, result pubsub.ValidationResult) {
+	messagesReceivedMetric.WithLabelValues(topic, validationResultLabel(result)).Inc()
+}
+
+func (m *metricsHandler) Broken(topic string, _ *pubsub.Message, _ error) {
+	messagesBrokenMetric.WithLabelValues(topic).Inc()
+}
+
+func validationResultLabel(result pubsub.ValidationResult) string {
+	switch result {
+	case pubsub.ValidationAccept:
+		return "accept"
+	case pubsub.ValidationReject:
+		return "reject"
+	case pubsub.ValidationIgnore:
+		return "ignore"
+	}
+	return "unknown"
+}
@@ -34,6 +34,8 @@ var errInvalidSignature = errors.New("received price has an invalid signature")
 var errInvalidPrice = errors.New("received price is invalid")
 var errUnknownPair = errors.New("received pair is not configured")
 var errUnknownFeeder = errors.New("feeder is not allowed to send prices")
+var errInvalidStarkSignature = errors.New("received price has an invalid stark signature")
+var errUnknownStarkKey = errors.New("stark public key of the feeder is not configured")
 
 // Datastore reads and stores prices from the P2P network.
 type Datastore struct {
@@ -41,17 +43,28 @@ type Datastore struct {
 	mu     sync.Mutex
 	doneCh chan struct{}
 
-	signer     ethereum.Signer
-	transport  transport.Transport
-	pairs      map[string]*Pair
-	priceStore *PriceStore
-	log        log.Logger
+	signer      ethereum.Signer
+	verifyStark bool
+	starkKeys   map[ethereum.Address]*big.Int
+	transport   transport.Transport
+	pairs       map[string]*Pair
+	priceStore  *PriceStore
+	log         log.Logger
 }
 
 type Config struct {
 	// Signer is an instance of the ethereum.Signer which will be used to
 	// verify price signatures.
 	Signer ethereum.Signer
+	// VerifyStarkSignatures enables verification of StarkEx signatures.
+	// If enabled, prices with an invalid stark signature or with a stark
+	// signature from a feeder without a configured stark key are rejected.
+	// Prices without the stark signature are still accepted.
+	VerifyStarkSignatures bool
+	// StarkKeys are the stark public keys of feeders. Stark signatures are
+	// verified using these keys, because the public key included in
+	// a price is not covered by the price signature.
+	StarkKeys map[ethereum.Address]*big.Int
 	// Transport is a implementation of transport used to fetch prices from
 	// feeders.
 	Transport transport.Transport
@@ -74,13 +87,15 @@ func NewDatastore(ctx context.Context, cfg Config) (*Datastore, error) {
 		return nil, errors.New("context must not be nil")
 	}
 	return &Datastore{
-		ctx:        ctx,
-		doneCh:     make(chan struct{}),
-		signer:     cfg.Signer,
-		transport:  cfg.Transport,
-		pairs:      cfg.Pairs,
-		priceStore: NewPriceStore(),
-		log:        cfg.Logger.WithField("tag", LoggerTag),
+		ctx:         ctx,
+		doneCh:      make(chan struct{}),
+		signer:      cfg.Signer,
+		verifyStark: cfg.VerifyStarkSignatures,
+		starkKeys:   cfg.StarkKeys,
+		transport:   cfg.Transport,
+		pairs:       cfg.Pairs,
+		priceStore:  NewPriceStore(),
+		log:         cfg.Logger.WithField("tag", LoggerTag),
 	}, nil
 }
 
@@ -119,12 +134,60 @@ func (c *Datastore) collectPrice(msg *messages.Price) error {
 	if msg.Price.Val.Cmp(big.NewInt(0)) <= 0 {
 		return errInvalidPrice
 	}
+	if c.verifyStark && msg.Price.HasStarkSignature() {
+		key, ok := c.starkKeys[*from]
+		if !ok {
+			return errUnknownStarkKey
+		}
+		if msg.Price.VerifyStark(key) != nil {
+			return errInvalidStarkSignature
+		}
+	}
 
 	c.priceStore.Add(*from, msg)
 
 	return nil
 }
 
+// collectPriceBundle adds prices from a price bundle. All prices in
+// the bundle must be signed by the same feeder who signed the bundle.
+func (c *Datastore) collectPriceBundle(bundle *messages.PriceBundle) error {
+	from, err := bundle.From(c.signer)
+	if err != nil {
+		return errInvalidSignature
+	}
+	for _, price := range bundle.Prices {
+		priceFrom, err := price.From(c.signer)
+		if err != nil || *priceFrom != *from {
+			return errInvalidSignature
+		}
+	}
+	for _, msg := range bundle.Messages() {
+		c.handlePrice(msg)
+	}
+	return nil
+}
+
+// handlePrice collects a price and logs the result.
+func (c *Datastore) handlePrice(price *messages.Price) {
+	// Try to collect received price:
+	err := c.collectPrice(price)
+
+	// Print logs:
+	if err != nil {
+		pricesRejectedMetric.WithLabelValues(price.Price.Wat, rejectReason(err)).Inc()
+		c.log.
+			WithError(err).
+			WithFields(price.Price.Fields(c.signer)).
+			Warn("Received invalid price")
+	} else {
+		pricesReceivedMetric.WithLabelValues(price.Price.Wat).Inc()
+		c.log.
+			WithFields(price.Price.Fields(c.signer)).
+			Info("Price received")
+	}
+}
+
 // collectorLoop creates a asynchronous loop which fetches prices from feeders.
 func (c *Datastore) collectorLoop() error {
 	go func() {
@@ -148,20 +211,24 @@ func (c *Datastore) collectorLoop() error {
 					c.log.Error("Unexpected value returned from transport layer")
 					continue
 				}
-
-				// Try to collect received price:
-				err := c.collectPrice(price)
-
-				// Print logs:
-				if err != nil {
+				c.handlePrice(price)
+			case m := <-c.transport.Messages(messages.PriceBundleMessageName):
+				if m.Error != nil {
 					c.log.
-						WithError(err).
-						WithFields(price.Price.Fields(c.signer)).
-						Warn("Received invalid price")
-				} else {
+						WithError(m.Error).
+						Warn("Unable to read price bundles from the transport")
+					continue
+				}
+				bundle, ok := m.Message.(*messages.PriceBundle)
+				if !ok {
+					c.log.Error("Unexpected value returned from transport layer")
+					continue
+				}
+				if err := c.collectPriceBundle(bundle); err != nil {
 					c.log.
-						WithFields(price.Price.Fields(c.signer)).
-						Info("Price received")
+						WithError(err).
+						WithField("prices", len(bundle.Prices)).
+						Warn("Received invalid price bundle")
 				}
 			}
 		}
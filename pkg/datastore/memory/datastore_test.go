@@ -16,7 +16,9 @@
 package memory
 
 import (
+	"bytes"
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
 	"github.com/makerdao/oracle-suite/pkg/log/null"
 	"github.com/makerdao/oracle-suite/pkg/oracle"
+	"github.com/makerdao/oracle-suite/pkg/starkex"
 	"github.com/makerdao/oracle-suite/pkg/transport"
 	"github.com/makerdao/oracle-suite/pkg/transport/local"
 	"github.com/makerdao/oracle-suite/pkg/transport/messages"
@@ -80,6 +83,95 @@ func TestDatastore_Prices(t *testing.T) {
 	assert.Contains(t, toOraclePrices(xxxyyy), testutil.PriceXXXYYY2.Price)
 }
 
+func TestDatastore_collectPrice_StarkSignature(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	sig := &mocks.Signer{}
+	sig.On("Recover", mock.Anything, mock.Anything).Return(&testutil.Address1, nil)
+
+	key, err := starkex.DerivePrivateKey([]byte("seed"))
+	require.NoError(t, err)
+	other, err := starkex.DerivePrivateKey([]byte("other"))
+	require.NoError(t, err)
+
+	ds, err := NewDatastore(ctx, Config{
+		Signer:                sig,
+		VerifyStarkSignatures: true,
+		StarkKeys:             map[ethereum.Address]*big.Int{testutil.Address1: key.PublicKey()},
+		Pairs:                 map[string]*Pair{"AAABBB": {Feeds: []ethereum.Address{testutil.Address1}}},
+		Logger:                null.New(),
+	})
+	require.NoError(t, err)
+
+	price := &oracle.Price{Wat: "AAABBB", Age: time.Unix(100, 0)}
+	price.SetFloat64Price(10)
+	require.NoError(t, price.SignStark(key))
+
+	// Valid signature:
+	assert.NoError(t, ds.collectPrice(&messages.Price{Price: price}))
+
+	// Signature made with a key other than the configured one, even if
+	// the key in the price matches the signature:
+	forged := *price
+	require.NoError(t, forged.SignStark(other))
+	assert.Equal(t, errInvalidStarkSignature, ds.collectPrice(&messages.Price{Price: &forged}))
+
+	// Invalid signature:
+	price.StarkS = []byte{1}
+	assert.Equal(t, errInvalidStarkSignature, ds.collectPrice(&messages.Price{Price: price}))
+
+	// Prices without the stark signature are accepted:
+	price.StarkR, price.StarkS, price.StarkPK = nil, nil, nil
+	assert.NoError(t, ds.collectPrice(&messages.Price{Price: price}))
+
+	// Stark signatures of feeders without a configured key cannot be
+	// verified:
+	ds.starkKeys = nil
+	require.NoError(t, price.SignStark(key))
+	assert.Equal(t, errUnknownStarkKey, ds.collectPrice(&messages.Price{Price: price}))
+}
+
+func TestDatastore_collectPriceBundle(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	sig := &mocks.Signer{}
+	ds, err := NewDatastore(ctx, Config{
+		Signer: sig,
+		Pairs: map[string]*Pair{
+			"AAABBB": {Feeds: []ethereum.Address{testutil.Address1, testutil.Address2}},
+			"XXXYYY": {Feeds: []ethereum.Address{testutil.Address1, testutil.Address2}},
+		},
+		Logger: null.New(),
+	})
+	require.NoError(t, err)
+
+	bundleSig := ethereum.SignatureFromBytes(bytes.Repeat([]byte{9}, ethereum.SignatureLength))
+	sig.On("Recover", bundleSig, mock.Anything).Return(&testutil.Address1, nil)
+	sig.On("Recover", testutil.PriceAAABBB1.Price.Signature(), mock.Anything).Return(&testutil.Address1, nil)
+	sig.On("Recover", testutil.PriceAAABBB2.Price.Signature(), mock.Anything).Return(&testutil.Address1, nil)
+	sig.On("Recover", testutil.PriceXXXYYY1.Price.Signature(), mock.Anything).Return(&testutil.Address1, nil)
+	sig.On("Recover", testutil.PriceXXXYYY2.Price.Signature(), mock.Anything).Return(&testutil.Address2, nil)
+
+	// All prices are signed by the author of the bundle:
+	assert.NoError(t, ds.collectPriceBundle(&messages.PriceBundle{
+		Prices:    []*oracle.Price{testutil.PriceAAABBB1.Price, testutil.PriceXXXYYY1.Price},
+		Signature: bundleSig,
+	}))
+	assert.Equal(t, []*oracle.Price{testutil.PriceAAABBB1.Price}, toOraclePrices(ds.Prices().AssetPair("AAABBB")))
+	assert.Equal(t, []*oracle.Price{testutil.PriceXXXYYY1.Price}, toOraclePrices(ds.Prices().AssetPair("XXXYYY")))
+
+	// The XXXYYY2 price is signed by another feeder, so the whole bundle
+	// must be rejected:
+	assert.Equal(t, errInvalidSignature, ds.collectPriceBundle(&messages.PriceBundle{
+		Prices:    []*oracle.Price{testutil.PriceAAABBB2.Price, testutil.PriceXXXYYY2.Price},
+		Signature: bundleSig,
+	}))
+	assert.Len(t, ds.Prices().AssetPair("AAABBB"), 1)
+	assert.Len(t, ds.Prices().AssetPair("XXXYYY"), 1)
+}
+
 func toOraclePrices(ps []*messages.Price) []*oracle.Price {
 	var r []*oracle.Price
 	for _, p := range ps {
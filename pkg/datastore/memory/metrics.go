@@ -0,0 +1,54 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pricesReceivedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "datastore",
+		Name:      "prices_received_total",
+		Help:      "Number of valid prices received from feeders.",
+	}, []string{"pair"})
+	pricesRejectedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "datastore",
+		Name:      "prices_rejected_total",
+		Help:      "Number of prices rejected by the datastore.",
+	}, []string{"pair", "reason"})
+)
+
+// rejectReason returns a reason label for an error returned by
+// the Datastore.collectPrice method.
+func rejectReason(err error) string {
+	switch err {
+	case errInvalidSignature:
+		return "invalid_signature"
+	case errInvalidPrice:
+		return "invalid_price"
+	case errUnknownPair:
+		return "unknown_pair"
+	case errUnknownFeeder:
+		return "unknown_feeder"
+	case errInvalidStarkSignature:
+		return "invalid_stark_signature"
+	case errUnknownStarkKey:
+		return "unknown_stark_key"
+	}
+	return "other"
+}
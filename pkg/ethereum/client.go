@@ -23,16 +23,19 @@ import (
 type Transaction struct {
 	// Address is the contract's address.
 	Address Address
-	// Nonce is the transaction nonce. If zero, the nonce will be filled
-	// automatically.
+	// Nonce is the transaction nonce. If zero and NonceSet is false, the
+	// nonce will be filled automatically.
 	Nonce uint64
+	// NonceSet indicates that the Nonce is set even if it is zero.
+	NonceSet bool
 	// PriorityFee is the maximum tip value. If nil, the suggested gas tip value
 	// will be used.
 	PriorityFee *big.Int
-	// MaxFee is the maximum fee value. If nil, double value of a suggested
-	// gas fee will be used.
+	// MaxFee is the maximum fee value. If nil, the suggested maximum fee
+	// will be used.
 	MaxFee *big.Int
 	// GasLimit is the maximum gas available to be used for this transaction.
+	// If nil, the gas limit will be estimated.
 	GasLimit *big.Int
 	// Data is the raw transaction data.
 	Data []byte
@@ -44,6 +47,35 @@ type Transaction struct {
 	SignedTx interface{}
 }
 
+type Receipt struct {
+	// TxHash is the hash of the mined transaction.
+	TxHash Hash
+	// BlockNumber is the number of the block in which the transaction
+	// was mined.
+	BlockNumber uint64
+	// Success is false if the transaction was reverted.
+	Success bool
+	// GasUsed is the amount of gas used by the transaction.
+	GasUsed uint64
+	// EffectiveGasPrice is the price paid for a single unit of gas.
+	EffectiveGasPrice *big.Int
+}
+
+// FeeHistory is the result of the eth_feeHistory call.
+type FeeHistory struct {
+	// OldestBlock is the number of the oldest block in the range.
+	OldestBlock *big.Int
+	// Reward contains priority fees at the requested percentiles for every
+	// block in the range.
+	Reward [][]*big.Int
+	// BaseFee contains base fees for every block in the range and for the
+	// next block after the range.
+	BaseFee []*big.Int
+	// GasUsedRatio contains ratios of gas used to the gas limit for every
+	// block in the range.
+	GasUsedRatio []float64
+}
+
 type Call struct {
 	// Address is the contract's address.
 	Address Address
@@ -64,4 +96,15 @@ type Client interface {
 	// SendTransaction injects a signed transaction into the pending pool
 	// for execution.
 	SendTransaction(ctx context.Context, transaction *Transaction) (*Hash, error)
+	// BlockNumber returns the number of the most recent block.
+	BlockNumber(ctx context.Context) (uint64, error)
+	// PendingNonce returns the nonce of the given account in the pending
+	// state.
+	PendingNonce(ctx context.Context, address Address) (uint64, error)
+	// SuggestFees returns the suggested priority fee and maximum fee for
+	// a new transaction.
+	SuggestFees(ctx context.Context) (priorityFee *big.Int, maxFee *big.Int, err error)
+	// TransactionReceipt returns the receipt of a mined transaction. If the
+	// transaction is not mined yet, nil is returned.
+	TransactionReceipt(ctx context.Context, hash Hash) (*Receipt, error)
 }
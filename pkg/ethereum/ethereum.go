@@ -34,6 +34,9 @@ type (
 // HexToAddress returns Address from hex representation.
 var HexToAddress = common.HexToAddress
 
+// HexToHash returns Hash from hex representation.
+var HexToHash = common.HexToHash
+
 // IsHexAddress verifies if given string is a valid Ethereum address.
 var IsHexAddress = common.IsHexAddress
 
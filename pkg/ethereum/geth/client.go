@@ -42,7 +42,8 @@ const (
 )
 
 // Addresses of multicall contracts. They're used to implement
-// the Client.MultiCall function.
+// the Client.MultiCall function. For other chains, the address can be set
+// using the WithMultiCallAddress option.
 //
 // https://github.com/makerdao/multicall
 var multiCallContracts = map[uint64]common.Address{
@@ -54,6 +55,10 @@ var multiCallContracts = map[uint64]common.Address{
 	xdaiChainID:    common.HexToAddress("0xb5b692a88bdfc81ca69dcb1d924f59f0413a602a"),
 }
 
+// defaultGasLimitMargin is the default margin in percent added to the
+// estimated gas.
+const defaultGasLimitMargin = 25
+
 var ErrMulticallNotSupported = errors.New("multicall is not supported on current chain")
 var ErrInvalidSignedTxType = errors.New("unable to send transaction, SignedTx field have invalid type")
 
@@ -81,20 +86,83 @@ type EthClient interface {
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	NetworkID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*pkgEthereum.FeeHistory, error)
 }
 
 // Client implements the ethereum.Client interface.
 type Client struct {
-	ethClient EthClient
-	signer    pkgEthereum.Signer
+	ethClient      EthClient
+	signer         pkgEthereum.Signer
+	feeStrategy    FeeStrategy
+	maxFee         *big.Int
+	gasLimitMargin float64
+	chainID        *big.Int
+	multiCallAddr  *common.Address
+}
+
+// ClientOption is an optional setting for the Client.
+type ClientOption func(c *Client)
+
+// WithFeeStrategy sets the strategy used to calculate fees of transactions
+// without fees. By default, the SuggestedFees strategy is used.
+func WithFeeStrategy(strategy FeeStrategy) ClientOption {
+	return func(c *Client) {
+		c.feeStrategy = strategy
+	}
+}
+
+// WithMaxFee sets the hard limit for the maximum fee of all sent
+// transactions. Transactions with greater fees are sent with the fee
+// lowered to this limit.
+func WithMaxFee(maxFee *big.Int) ClientOption {
+	return func(c *Client) {
+		c.maxFee = maxFee
+	}
+}
+
+// WithGasLimitMargin sets the margin in percent added to the estimated gas
+// of transactions without the gas limit.
+func WithGasLimitMargin(margin float64) ClientOption {
+	return func(c *Client) {
+		c.gasLimitMargin = margin
+	}
+}
+
+// WithChainID sets the chain ID used to sign transactions. By default, the
+// network ID reported by the node is used, which may be different from the
+// chain ID on some networks.
+func WithChainID(chainID uint64) ClientOption {
+	return func(c *Client) {
+		c.chainID = new(big.Int).SetUint64(chainID)
+	}
+}
+
+// WithMultiCallAddress sets the address of the multicall contract used by
+// the MultiCall method. By default, the address is selected based on
+// the chain ID.
+func WithMultiCallAddress(address common.Address) ClientOption {
+	return func(c *Client) {
+		c.multiCallAddr = &address
+	}
 }
 
 // NewClient returns a new Client instance.
-func NewClient(ethClient EthClient, signer pkgEthereum.Signer) *Client {
-	return &Client{
-		ethClient: ethClient,
-		signer:    signer,
+func NewClient(ethClient EthClient, signer pkgEthereum.Signer, opts ...ClientOption) *Client {
+	c := &Client{
+		ethClient:      ethClient,
+		signer:         signer,
+		feeStrategy:    SuggestedFees{Multiplier: 1},
+		gasLimitMargin: defaultGasLimitMargin,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Call implements the ethereum.Client interface.
@@ -141,14 +209,10 @@ func (e *Client) MultiCall(ctx context.Context, calls []pkgEthereum.Call) ([][]b
 		})
 	}
 
-	chainID, err := e.ethClient.NetworkID(ctx)
+	multicallAddr, err := e.multiCallContract(ctx)
 	if err != nil {
 		return nil, err
 	}
-	multicallAddr, ok := multiCallContracts[chainID.Uint64()]
-	if !ok {
-		return nil, ErrMulticallNotSupported
-	}
 	callData, err := multiCallABI.Pack("aggregate", abiCalls)
 	if err != nil {
 		return nil, err
@@ -165,6 +229,32 @@ func (e *Client) MultiCall(ctx context.Context, calls []pkgEthereum.Call) ([][]b
 	return results[1].([][]byte), nil
 }
 
+// networkChainID returns the configured chain ID or the network ID
+// reported by the node if the chain ID is not set.
+func (e *Client) networkChainID(ctx context.Context) (*big.Int, error) {
+	if e.chainID != nil {
+		return e.chainID, nil
+	}
+	return e.ethClient.NetworkID(ctx)
+}
+
+// multiCallContract returns the address of the multicall contract for
+// the current chain.
+func (e *Client) multiCallContract(ctx context.Context) (common.Address, error) {
+	if e.multiCallAddr != nil {
+		return *e.multiCallAddr, nil
+	}
+	chainID, err := e.networkChainID(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	addr, ok := multiCallContracts[chainID.Uint64()]
+	if !ok {
+		return common.Address{}, ErrMulticallNotSupported
+	}
+	return addr, nil
+}
+
 // Storage implements the ethereum.Client interface.
 func (e *Client) Storage(ctx context.Context, address pkgEthereum.Address, key pkgEthereum.Hash) ([]byte, error) {
 	return e.ethClient.StorageAt(ctx, address, key, nil)
@@ -179,6 +269,7 @@ func (e *Client) SendTransaction(ctx context.Context, transaction *pkgEthereum.T
 	tx := &pkgEthereum.Transaction{
 		Address:     transaction.Address,
 		Nonce:       transaction.Nonce,
+		NonceSet:    transaction.NonceSet,
 		PriorityFee: transaction.PriorityFee,
 		MaxFee:      transaction.MaxFee,
 		GasLimit:    transaction.GasLimit,
@@ -189,28 +280,33 @@ func (e *Client) SendTransaction(ctx context.Context, transaction *pkgEthereum.T
 	copy(tx.Data, transaction.Data)
 
 	// Fill optional values if necessary:
-	if tx.Nonce == 0 {
+	if tx.Nonce == 0 && !tx.NonceSet {
 		tx.Nonce, err = e.ethClient.PendingNonceAt(ctx, e.signer.Address())
 		if err != nil {
 			return nil, err
 		}
 	}
-	if tx.PriorityFee == nil {
-		suggestedGasTipPrice, err := e.ethClient.SuggestGasTipCap(ctx)
+	if tx.PriorityFee == nil || tx.MaxFee == nil {
+		priorityFee, maxFee, err := e.SuggestFees(ctx)
 		if err != nil {
 			return nil, err
 		}
-		tx.PriorityFee = suggestedGasTipPrice
+		if tx.PriorityFee == nil {
+			tx.PriorityFee = priorityFee
+		}
+		if tx.MaxFee == nil {
+			tx.MaxFee = maxFee
+		}
 	}
-	if tx.MaxFee == nil {
-		suggestedGasPrice, err := e.ethClient.SuggestGasPrice(ctx)
+	tx.PriorityFee, tx.MaxFee = capFees(tx.PriorityFee, tx.MaxFee, e.maxFee)
+	if tx.GasLimit == nil {
+		tx.GasLimit, err = e.estimateGas(ctx, tx)
 		if err != nil {
 			return nil, err
 		}
-		tx.MaxFee = new(big.Int).Mul(suggestedGasPrice, big.NewInt(2))
 	}
 	if tx.ChainID == nil {
-		tx.ChainID, err = e.ethClient.NetworkID(ctx)
+		tx.ChainID, err = e.networkChainID(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -230,6 +326,86 @@ func (e *Client) SendTransaction(ctx context.Context, transaction *pkgEthereum.T
 	return nil, ErrInvalidSignedTxType
 }
 
+// BlockNumber implements the ethereum.Client interface.
+func (e *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	return e.ethClient.BlockNumber(ctx)
+}
+
+// PendingNonce implements the ethereum.Client interface.
+func (e *Client) PendingNonce(ctx context.Context, address pkgEthereum.Address) (uint64, error) {
+	return e.ethClient.PendingNonceAt(ctx, address)
+}
+
+// SuggestFees implements the ethereum.Client interface. Fees are
+// calculated using the client's fee strategy and limited to the maximum
+// fee.
+func (e *Client) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	priorityFee, maxFee, err := e.feeStrategy.Fees(ctx, e.ethClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	priorityFee, maxFee = capFees(priorityFee, maxFee, e.maxFee)
+	return priorityFee, maxFee, nil
+}
+
+// estimateGas estimates the gas needed to execute the transaction and adds
+// the safety margin to it.
+func (e *Client) estimateGas(ctx context.Context, tx *pkgEthereum.Transaction) (*big.Int, error) {
+	gas, err := e.ethClient.EstimateGas(ctx, ethereum.CallMsg{
+		From: e.signer.Address(),
+		To:   &tx.Address,
+		Data: tx.Data,
+	})
+	if err := isRevertErr(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return multiply(new(big.Int).SetUint64(gas), 1+e.gasLimitMargin/100), nil
+}
+
+// TransactionReceipt implements the ethereum.Client interface.
+func (e *Client) TransactionReceipt(ctx context.Context, hash pkgEthereum.Hash) (*pkgEthereum.Receipt, error) {
+	receipt, err := e.ethClient.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The receipt returned by go-ethereum does not contain the effective
+	// gas price, so it has to be calculated using the block's base fee:
+	tx, _, err := e.ethClient.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := e.ethClient.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &pkgEthereum.Receipt{
+		TxHash:            receipt.TxHash,
+		BlockNumber:       receipt.BlockNumber.Uint64(),
+		Success:           receipt.Status == types.ReceiptStatusSuccessful,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: effectiveGasPrice(tx, header.BaseFee),
+	}, nil
+}
+
+// effectiveGasPrice returns the price paid for a single unit of gas by
+// the transaction in a block with the given base fee.
+func effectiveGasPrice(tx *types.Transaction, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return tx.GasPrice()
+	}
+	price := new(big.Int).Add(baseFee, tx.GasTipCap())
+	if price.Cmp(tx.GasFeeCap()) > 0 {
+		return new(big.Int).Set(tx.GasFeeCap())
+	}
+	return price
+}
+
 func isRevertResp(resp []byte) error {
 	revert, err := abi.UnpackRevert(resp)
 	if err != nil {
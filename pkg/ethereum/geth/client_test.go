@@ -158,6 +158,38 @@ func TestClient_MultiCall(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_MultiCall_CustomAddress(t *testing.T) {
+	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
+	ethClient := &mocks.EthClient{}
+	multiCallAddr := common.HexToAddress("0x1122344556677889900112233445566778899003")
+	client := NewClient(ethClient, NewSigner(account), WithMultiCallAddress(multiCallAddr))
+
+	ethClient.On(
+		"CallContract",
+		mock.Anything,
+		mock.Anything,
+		(*big.Int)(nil),
+	).Return(clientMultiCallResp, nil)
+
+	resp, err := client.MultiCall(
+		context.Background(),
+		[]pkgEthereum.Call{
+			{Address: clientContractAddress, Data: clientCallData},
+			{Address: clientContractAddress, Data: clientCallData},
+			{Address: clientContractAddress, Data: clientCallData},
+			{Address: clientContractAddress, Data: clientCallData},
+		},
+	)
+
+	// The network ID should not be queried if the address is set:
+	cm := ethClient.Calls[0].Arguments.Get(1).(ethereum.CallMsg)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp, 4)
+	assert.Equal(t, multiCallAddr, *cm.To)
+	ethClient.AssertNotCalled(t, "NetworkID", mock.Anything)
+}
+
 func TestClient_Storage(t *testing.T) {
 	ethClient := &mocks.EthClient{}
 	client := NewClient(ethClient, NewSigner(nil))
@@ -215,6 +247,36 @@ func TestClient_SendTransaction(t *testing.T) {
 	assert.Equal(t, clientCallData, stx.Data())
 }
 
+func TestClient_SendTransaction_ZeroNonce(t *testing.T) {
+	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, NewSigner(account))
+
+	ethClient.On(
+		"SendTransaction",
+		mock.Anything,
+		mock.Anything,
+	).Return(nil)
+
+	tx := &pkgEthereum.Transaction{
+		Address:     clientContractAddress,
+		Nonce:       0,
+		NonceSet:    true,
+		PriorityFee: big.NewInt(50),
+		MaxFee:      big.NewInt(100),
+		GasLimit:    big.NewInt(1000),
+		Data:        clientCallData,
+		ChainID:     big.NewInt(mainnetChainID),
+	}
+
+	_, err := client.SendTransaction(context.Background(), tx)
+	stx := ethClient.Calls[0].Arguments.Get(1).(*types.Transaction)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), stx.Nonce())
+	ethClient.AssertNotCalled(t, "PendingNonceAt", mock.Anything, mock.Anything)
+}
+
 func TestClient_SendTransaction_Minimal(t *testing.T) {
 	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
 	ethClient := &mocks.EthClient{}
@@ -266,3 +328,107 @@ func TestClient_SendTransaction_Minimal(t *testing.T) {
 	assert.Equal(t, uint64(1000), stx.Gas())
 	assert.Equal(t, big.NewInt(mainnetChainID), stx.ChainId())
 }
+
+func TestClient_SendTransaction_ChainID(t *testing.T) {
+	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, NewSigner(account), WithChainID(10))
+
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	tx := &pkgEthereum.Transaction{
+		Address:     clientContractAddress,
+		Nonce:       10,
+		PriorityFee: big.NewInt(10),
+		MaxFee:      big.NewInt(20),
+		GasLimit:    big.NewInt(1000),
+		Data:        clientCallData,
+	}
+
+	_, err := client.SendTransaction(context.Background(), tx)
+	assert.NoError(t, err)
+
+	// The network ID should not be queried if the chain ID is set:
+	stx := ethClient.Calls[0].Arguments.Get(1).(*types.Transaction)
+	assert.Equal(t, big.NewInt(10), stx.ChainId())
+	ethClient.AssertNotCalled(t, "NetworkID", mock.Anything)
+}
+
+func TestClient_TransactionReceipt(t *testing.T) {
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, nil)
+
+	hash := common.HexToHash("0x01")
+	stx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(100),
+	})
+
+	ethClient.On("TransactionReceipt", mock.Anything, hash).Return(&types.Receipt{
+		TxHash:      hash,
+		BlockNumber: big.NewInt(42),
+		Status:      types.ReceiptStatusFailed,
+		GasUsed:     21000,
+	}, nil)
+	ethClient.On("TransactionByHash", mock.Anything, hash).Return(stx, false, nil)
+	ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(42)).Return(&types.Header{BaseFee: big.NewInt(50)}, nil)
+
+	receipt, err := client.TransactionReceipt(context.Background(), hash)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, receipt.TxHash)
+	assert.Equal(t, uint64(42), receipt.BlockNumber)
+	assert.False(t, receipt.Success)
+	assert.Equal(t, uint64(21000), receipt.GasUsed)
+	assert.Equal(t, big.NewInt(60), receipt.EffectiveGasPrice)
+}
+
+func TestClient_TransactionReceipt_NotFound(t *testing.T) {
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, nil)
+
+	ethClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return((*types.Receipt)(nil), ethereum.NotFound)
+
+	receipt, err := client.TransactionReceipt(context.Background(), common.HexToHash("0x01"))
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+}
+
+func TestClient_SendTransaction_EstimateGas(t *testing.T) {
+	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, NewSigner(account), WithMaxFee(big.NewInt(80)))
+
+	ethClient.On(
+		"EstimateGas",
+		mock.Anything,
+		mock.Anything,
+	).Return(uint64(100000), nil)
+
+	ethClient.On(
+		"SendTransaction",
+		mock.Anything,
+		mock.Anything,
+	).Return(nil)
+
+	tx := &pkgEthereum.Transaction{
+		Address:     clientContractAddress,
+		Nonce:       10,
+		PriorityFee: big.NewInt(50),
+		MaxFee:      big.NewInt(100),
+		Data:        clientCallData,
+		ChainID:     big.NewInt(mainnetChainID),
+	}
+
+	hash, err := client.SendTransaction(context.Background(), tx)
+	cm := ethClient.Calls[0].Arguments.Get(1).(ethereum.CallMsg)
+	stx := ethClient.Calls[1].Arguments.Get(1).(*types.Transaction)
+
+	assert.NotNil(t, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, clientAddress, cm.From)
+	assert.Equal(t, clientContractAddress, *cm.To)
+	assert.Equal(t, clientCallData, cm.Data)
+	assert.Equal(t, uint64(125000), stx.Gas())
+	assert.Equal(t, big.NewInt(80), stx.GasFeeCap())
+	assert.Equal(t, big.NewInt(50), stx.GasTipCap())
+}
@@ -0,0 +1,124 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var ErrNoFeeHistory = errors.New("fee history is empty")
+
+// FeeStrategy calculates fees for new transactions.
+type FeeStrategy interface {
+	// Fees returns the priority fee and the maximum fee for a new
+	// transaction.
+	Fees(ctx context.Context, client EthClient) (priorityFee *big.Int, maxFee *big.Int, err error)
+}
+
+// FixedFees always returns the same fees.
+type FixedFees struct {
+	PriorityFee *big.Int
+	MaxFee      *big.Int
+}
+
+// Fees implements the FeeStrategy interface.
+func (f FixedFees) Fees(_ context.Context, _ EthClient) (*big.Int, *big.Int, error) {
+	return new(big.Int).Set(f.PriorityFee), new(big.Int).Set(f.MaxFee), nil
+}
+
+// SuggestedFees uses the suggested gas tip as the priority fee and double
+// the suggested gas price as the maximum fee. Both values are multiplied
+// by the Multiplier.
+type SuggestedFees struct {
+	Multiplier float64
+}
+
+// Fees implements the FeeStrategy interface.
+func (f SuggestedFees) Fees(ctx context.Context, client EthClient) (*big.Int, *big.Int, error) {
+	suggestedGasTipPrice, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	suggestedGasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxFee := new(big.Int).Mul(suggestedGasPrice, big.NewInt(2))
+	return multiply(suggestedGasTipPrice, f.Multiplier), multiply(maxFee, f.Multiplier), nil
+}
+
+// FeeHistoryFees calculates fees using the eth_feeHistory method. The
+// priority fee is the median of priority fees at the given Percentile
+// in the last Blocks blocks, multiplied by the Multiplier. The maximum fee
+// is double the next block's base fee plus the priority fee.
+type FeeHistoryFees struct {
+	Blocks     uint64
+	Percentile float64
+	Multiplier float64
+}
+
+// Fees implements the FeeStrategy interface.
+func (f FeeHistoryFees) Fees(ctx context.Context, client EthClient) (*big.Int, *big.Int, error) {
+	fh, err := client.FeeHistory(ctx, f.Blocks, nil, []float64{f.Percentile})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(fh.BaseFee) == 0 || len(fh.Reward) == 0 {
+		return nil, nil, ErrNoFeeHistory
+	}
+	var rewards []*big.Int
+	for _, r := range fh.Reward {
+		if len(r) > 0 && r[0] != nil {
+			rewards = append(rewards, r[0])
+		}
+	}
+	if len(rewards) == 0 {
+		return nil, nil, ErrNoFeeHistory
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
+	})
+	priorityFee := multiply(rewards[len(rewards)/2], f.Multiplier)
+	nextBaseFee := fh.BaseFee[len(fh.BaseFee)-1]
+	maxFee := new(big.Int).Mul(nextBaseFee, big.NewInt(2))
+	maxFee.Add(maxFee, priorityFee)
+	return priorityFee, maxFee, nil
+}
+
+// multiply multiplies the value by m. If m is zero, the value is
+// returned unchanged.
+func multiply(v *big.Int, m float64) *big.Int {
+	if m == 0 || m == 1 {
+		return new(big.Int).Set(v)
+	}
+	r, _ := new(big.Float).Mul(new(big.Float).SetInt(v), big.NewFloat(m)).Int(nil)
+	return r
+}
+
+// capFees limits the maximum fee to the ceiling. The priority fee is never
+// greater than the maximum fee. If ceiling is nil, fees are not limited.
+func capFees(priorityFee, maxFee, ceiling *big.Int) (*big.Int, *big.Int) {
+	if ceiling != nil && maxFee != nil && maxFee.Cmp(ceiling) > 0 {
+		maxFee = new(big.Int).Set(ceiling)
+	}
+	if priorityFee != nil && maxFee != nil && priorityFee.Cmp(maxFee) > 0 {
+		priorityFee = new(big.Int).Set(maxFee)
+	}
+	return priorityFee, maxFee
+}
@@ -0,0 +1,74 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pkgEthereum "github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/geth/mocks"
+)
+
+func TestFixedFees(t *testing.T) {
+	priorityFee, maxFee, err := FixedFees{PriorityFee: big.NewInt(1), MaxFee: big.NewInt(2)}.Fees(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), priorityFee)
+	assert.Equal(t, big.NewInt(2), maxFee)
+}
+
+func TestSuggestedFees(t *testing.T) {
+	ethClient := &mocks.EthClient{}
+	ethClient.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(10), nil)
+	ethClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(100), nil)
+
+	priorityFee, maxFee, err := SuggestedFees{Multiplier: 1.5}.Fees(context.Background(), ethClient)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(15), priorityFee)
+	assert.Equal(t, big.NewInt(300), maxFee)
+}
+
+func TestFeeHistoryFees(t *testing.T) {
+	ethClient := &mocks.EthClient{}
+	ethClient.On("FeeHistory", mock.Anything, uint64(3), (*big.Int)(nil), []float64{50}).Return(&pkgEthereum.FeeHistory{
+		Reward:  [][]*big.Int{{big.NewInt(30)}, {big.NewInt(10)}, {big.NewInt(20)}},
+		BaseFee: []*big.Int{big.NewInt(100), big.NewInt(110), big.NewInt(120), big.NewInt(130)},
+	}, nil)
+
+	priorityFee, maxFee, err := FeeHistoryFees{Blocks: 3, Percentile: 50, Multiplier: 2}.Fees(context.Background(), ethClient)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(40), priorityFee)
+	assert.Equal(t, big.NewInt(300), maxFee)
+}
+
+func TestClient_SuggestFees_MaxFee(t *testing.T) {
+	client := NewClient(
+		&mocks.EthClient{},
+		nil,
+		WithFeeStrategy(FixedFees{PriorityFee: big.NewInt(20), MaxFee: big.NewInt(200)}),
+		WithMaxFee(big.NewInt(15)),
+	)
+
+	priorityFee, maxFee, err := client.SuggestFees(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(15), priorityFee)
+	assert.Equal(t, big.NewInt(15), maxFee)
+}
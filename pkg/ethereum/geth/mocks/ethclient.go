@@ -23,6 +23,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/mock"
+
+	pkgEthereum "github.com/makerdao/oracle-suite/pkg/ethereum"
 )
 
 type EthClient struct {
@@ -68,3 +70,39 @@ func (e *EthClient) NetworkID(ctx context.Context) (*big.Int, error) {
 	args := e.Called(ctx)
 	return args.Get(0).(*big.Int), args.Error(1)
 }
+
+func (e *EthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	args := e.Called(ctx)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (e *EthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	args := e.Called(ctx, txHash)
+	return args.Get(0).(*types.Receipt), args.Error(1)
+}
+
+func (e *EthClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	args := e.Called(ctx, hash)
+	return args.Get(0).(*types.Transaction), args.Bool(1), args.Error(2)
+}
+
+func (e *EthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	args := e.Called(ctx, number)
+	return args.Get(0).(*types.Header), args.Error(1)
+}
+
+func (e *EthClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	args := e.Called(ctx, call)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (e *EthClient) FeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	lastBlock *big.Int,
+	rewardPercentiles []float64,
+) (*pkgEthereum.FeeHistory, error) {
+
+	args := e.Called(ctx, blockCount, lastBlock, rewardPercentiles)
+	return args.Get(0).(*pkgEthereum.FeeHistory), args.Error(1)
+}
@@ -0,0 +1,169 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+const (
+	// ClefAPI uses the Clef's account_signData and account_signTransaction
+	// methods.
+	ClefAPI = "clef"
+	// Web3SignerAPI uses the Web3Signer's eth_sign and eth_signTransaction
+	// methods.
+	Web3SignerAPI = "web3signer"
+)
+
+// remoteSignerTimeout is the maximum time for a single signing request.
+const remoteSignerTimeout = 30 * time.Second
+
+var ErrInvalidRemoteSignature = errors.New("remote signer returned invalid signature")
+
+// RemoteSigner implements the ethereum.Signer interface. Unlike the Signer,
+// it does not hold private keys, instead it delegates signing to an external
+// signer, like Clef or Web3Signer, using the JSON-RPC API.
+type RemoteSigner struct {
+	rpc     *rpc.Client
+	api     string
+	address ethereum.Address
+}
+
+// NewRemoteSigner returns a new RemoteSigner instance. The api argument
+// must be either ClefAPI or Web3SignerAPI. The address is an account
+// managed by the remote signer which will be used to sign data.
+func NewRemoteSigner(client *rpc.Client, api string, address ethereum.Address) (*RemoteSigner, error) {
+	if api != ClefAPI && api != Web3SignerAPI {
+		return nil, fmt.Errorf("unsupported remote signer API: %s", api)
+	}
+	return &RemoteSigner{
+		rpc:     client,
+		api:     api,
+		address: address,
+	}, nil
+}
+
+// Address implements the ethereum.Signer interface.
+func (s *RemoteSigner) Address() ethereum.Address {
+	return s.address
+}
+
+// SignTransaction implements the ethereum.Signer interface.
+func (s *RemoteSigner) SignTransaction(transaction *ethereum.Transaction) error {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), remoteSignerTimeout)
+	defer ctxCancel()
+
+	args := remoteTxArgs{
+		From:    s.address,
+		To:      &transaction.Address,
+		Nonce:   hexutil.Uint64(transaction.Nonce),
+		Data:    transaction.Data,
+		Value:   (*hexutil.Big)(common.Big0),
+		ChainID: (*hexutil.Big)(transaction.ChainID),
+	}
+	if transaction.GasLimit != nil {
+		args.Gas = hexutil.Uint64(transaction.GasLimit.Uint64())
+	}
+	if transaction.MaxFee != nil {
+		args.MaxFeePerGas = (*hexutil.Big)(transaction.MaxFee)
+	}
+	if transaction.PriorityFee != nil {
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(transaction.PriorityFee)
+	}
+
+	var raw hexutil.Bytes
+	switch s.api {
+	case ClefAPI:
+		var res struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := s.rpc.CallContext(ctx, &res, "account_signTransaction", args); err != nil {
+			return err
+		}
+		raw = res.Raw
+	case Web3SignerAPI:
+		if err := s.rpc.CallContext(ctx, &raw, "eth_signTransaction", args); err != nil {
+			return err
+		}
+	}
+
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return fmt.Errorf("remote signer returned invalid transaction: %w", err)
+	}
+	transaction.SignedTx = tx
+	return nil
+}
+
+// Signature implements the ethereum.Signer interface. The data is signed
+// with the "\x19Ethereum Signed Message:\n" prefix, just like the Signer
+// does.
+func (s *RemoteSigner) Signature(data []byte) (ethereum.Signature, error) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), remoteSignerTimeout)
+	defer ctxCancel()
+
+	var res hexutil.Bytes
+	var err error
+	switch s.api {
+	case ClefAPI:
+		err = s.rpc.CallContext(ctx, &res, "account_signData", "text/plain", s.address, hexutil.Bytes(data))
+	case Web3SignerAPI:
+		err = s.rpc.CallContext(ctx, &res, "eth_sign", s.address, hexutil.Bytes(data))
+	}
+	if err != nil {
+		return ethereum.Signature{}, err
+	}
+	if len(res) != ethereum.SignatureLength {
+		return ethereum.Signature{}, ErrInvalidRemoteSignature
+	}
+
+	// Some signers return V as 0/1, transform it to 27/28 according to
+	// the yellow paper:
+	if res[64] < 27 {
+		res[64] += 27
+	}
+
+	return ethereum.SignatureFromBytes(res), nil
+}
+
+// Recover implements the ethereum.Signer interface.
+func (s *RemoteSigner) Recover(signature ethereum.Signature, data []byte) (*ethereum.Address, error) {
+	return Recover(signature, data)
+}
+
+// remoteTxArgs represents transaction arguments accepted by both, Clef and
+// Web3Signer.
+type remoteTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+}
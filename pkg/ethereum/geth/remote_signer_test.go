@@ -0,0 +1,165 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+// testRemoteSigner implements a subset of the Clef and Web3Signer APIs.
+type testRemoteSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func (s *testRemoteSigner) sign(addr common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if addr != s.address {
+		return nil, errors.New("unknown account")
+	}
+	sig, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+func (s *testRemoteSigner) signTx(args remoteTxArgs) (hexutil.Bytes, error) {
+	if args.From != s.address {
+		return nil, errors.New("unknown account")
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   (*big.Int)(args.ChainID),
+		Nonce:     uint64(args.Nonce),
+		GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+		GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     (*big.Int)(args.Value),
+		Data:      args.Data,
+	})
+	signedTx, err := types.SignTx(tx, types.NewLondonSigner((*big.Int)(args.ChainID)), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signedTx.MarshalBinary()
+}
+
+type testClefAPI struct{ s *testRemoteSigner }
+
+func (a *testClefAPI) SignData(contentType string, addr common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if contentType != "text/plain" {
+		return nil, errors.New("unsupported content type")
+	}
+	return a.s.sign(addr, data)
+}
+
+func (a *testClefAPI) SignTransaction(args remoteTxArgs) (map[string]interface{}, error) {
+	raw, err := a.s.signTx(args)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"raw": raw}, nil
+}
+
+type testWeb3SignerAPI struct{ s *testRemoteSigner }
+
+func (a *testWeb3SignerAPI) Sign(addr common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	return a.s.sign(addr, data)
+}
+
+func (a *testWeb3SignerAPI) SignTransaction(args remoteTxArgs) (hexutil.Bytes, error) {
+	return a.s.signTx(args)
+}
+
+func newTestRemoteSigner(t *testing.T, api string) *RemoteSigner {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := &testRemoteSigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("account", &testClefAPI{s: s}))
+	require.NoError(t, srv.RegisterName("eth", &testWeb3SignerAPI{s: s}))
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(httpSrv.Close)
+
+	cli, err := rpc.DialHTTP(httpSrv.URL)
+	require.NoError(t, err)
+	signer, err := NewRemoteSigner(cli, api, s.address)
+	require.NoError(t, err)
+	return signer
+}
+
+func TestRemoteSigner_Signature(t *testing.T) {
+	for _, api := range []string{ClefAPI, Web3SignerAPI} {
+		t.Run(api, func(t *testing.T) {
+			signer := newTestRemoteSigner(t, api)
+
+			signature, err := signer.Signature(signerData)
+			require.NoError(t, err)
+
+			address, err := signer.Recover(signature, signerData)
+			require.NoError(t, err)
+			assert.Equal(t, signer.Address(), *address)
+		})
+	}
+}
+
+func TestRemoteSigner_SignTransaction(t *testing.T) {
+	for _, api := range []string{ClefAPI, Web3SignerAPI} {
+		t.Run(api, func(t *testing.T) {
+			signer := newTestRemoteSigner(t, api)
+
+			tx := &ethereum.Transaction{
+				Address:     common.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4"),
+				Nonce:       10,
+				PriorityFee: big.NewInt(1),
+				MaxFee:      big.NewInt(2),
+				GasLimit:    big.NewInt(100000),
+				Data:        []byte{1, 2, 3},
+				ChainID:     big.NewInt(1),
+			}
+			require.NoError(t, signer.SignTransaction(tx))
+
+			signedTx := tx.SignedTx.(*types.Transaction)
+			sender, err := types.Sender(types.NewLondonSigner(big.NewInt(1)), signedTx)
+			require.NoError(t, err)
+			assert.Equal(t, signer.Address(), sender)
+			assert.Equal(t, uint64(10), signedTx.Nonce())
+			assert.Equal(t, []byte{1, 2, 3}, signedTx.Data())
+		})
+	}
+}
+
+func TestNewRemoteSigner_InvalidAPI(t *testing.T) {
+	_, err := NewRemoteSigner(nil, "foo", common.Address{})
+	assert.Error(t, err)
+}
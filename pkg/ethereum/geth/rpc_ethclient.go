@@ -0,0 +1,82 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	pkgEthereum "github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+// RPCEthClient extends the ethclient.Client with methods which are not
+// available in the ethclient package.
+type RPCEthClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// NewRPCEthClient returns a new RPCEthClient instance.
+func NewRPCEthClient(c *rpc.Client) *RPCEthClient {
+	return &RPCEthClient{
+		Client: ethclient.NewClient(c),
+		rpc:    c,
+	}
+}
+
+// FeeHistory returns the fee market history for the given number of blocks
+// up to the lastBlock. If lastBlock is nil, the latest block is used.
+func (e *RPCEthClient) FeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	lastBlock *big.Int,
+	rewardPercentiles []float64,
+) (*pkgEthereum.FeeHistory, error) {
+
+	var res struct {
+		OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+		Reward       [][]*hexutil.Big `json:"reward"`
+		BaseFee      []*hexutil.Big   `json:"baseFeePerGas"`
+		GasUsedRatio []float64        `json:"gasUsedRatio"`
+	}
+	block := "latest"
+	if lastBlock != nil {
+		block = hexutil.EncodeBig(lastBlock)
+	}
+	err := e.rpc.CallContext(ctx, &res, "eth_feeHistory", hexutil.Uint(blockCount), block, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	fh := &pkgEthereum.FeeHistory{
+		OldestBlock:  (*big.Int)(res.OldestBlock),
+		GasUsedRatio: res.GasUsedRatio,
+	}
+	for _, r := range res.Reward {
+		var rewards []*big.Int
+		for _, v := range r {
+			rewards = append(rewards, (*big.Int)(v))
+		}
+		fh.Reward = append(fh.Reward, rewards)
+	}
+	for _, v := range res.BaseFee {
+		fh.BaseFee = append(fh.BaseFee, (*big.Int)(v))
+	}
+	return fh, nil
+}
@@ -17,6 +17,7 @@ package mocks
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/stretchr/testify/mock"
 
@@ -46,3 +47,23 @@ func (c *Client) SendTransaction(ctx context.Context, transaction *ethereum.Tran
 	args := c.Called(ctx, transaction)
 	return args.Get(0).(*ethereum.Hash), args.Error(1)
 }
+
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	args := c.Called(ctx)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (c *Client) PendingNonce(ctx context.Context, address ethereum.Address) (uint64, error) {
+	args := c.Called(ctx, address)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (c *Client) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	args := c.Called(ctx)
+	return args.Get(0).(*big.Int), args.Get(1).(*big.Int), args.Error(2)
+}
+
+func (c *Client) TransactionReceipt(ctx context.Context, hash ethereum.Hash) (*ethereum.Receipt, error) {
+	args := c.Called(ctx, hash)
+	return args.Get(0).(*ethereum.Receipt), args.Error(1)
+}
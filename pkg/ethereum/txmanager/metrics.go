@@ -0,0 +1,49 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package txmanager
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	transactionsSentMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "txmanager",
+		Name:      "transactions_sent_total",
+		Help:      "Number of transactions sent.",
+	}, []string{"address"})
+	transactionsResentMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "txmanager",
+		Name:      "transactions_resent_total",
+		Help:      "Number of transactions resent with bumped fees.",
+	}, []string{"address"})
+	transactionsStuckMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "txmanager",
+		Name:      "transactions_stuck_total",
+		Help:      "Number of transactions which cannot be resent because of the maximum fee.",
+	}, []string{"address"})
+	transactionsFinishedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "txmanager",
+		Name:      "transactions_finished_total",
+		Help:      "Number of transactions which are no longer pending, by status.",
+	}, []string{"address", "status"})
+	pendingTransactionsMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "txmanager",
+		Name:      "pending_transactions",
+		Help:      "Number of transactions waiting to be mined.",
+	})
+)
@@ -0,0 +1,103 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package txmanager
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+// NonceManager reserves transaction nonces locally, so multiple transactions
+// may be sent without asking the node for the pending nonce every time.
+// This prevents duplicated and skipped nonces when the node's pending state
+// is not up to date, e.g. when requests are load-balanced between nodes.
+type NonceManager struct {
+	mu     sync.Mutex
+	client ethereum.Client
+	nonces map[ethereum.Address]uint64
+}
+
+// NewNonceManager returns a new instance of the NonceManager.
+func NewNonceManager(client ethereum.Client) *NonceManager {
+	return &NonceManager{
+		client: client,
+		nonces: make(map[ethereum.Address]uint64),
+	}
+}
+
+// Next reserves and returns the next nonce for the given address. If the
+// nonce is not known yet, it is fetched from the node.
+func (n *NonceManager) Next(ctx context.Context, address ethereum.Address) (uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	nonce, ok := n.nonces[address]
+	if !ok {
+		var err error
+		nonce, err = n.client.PendingNonce(ctx, address)
+		if err != nil {
+			return 0, err
+		}
+	}
+	n.nonces[address] = nonce + 1
+	return nonce, nil
+}
+
+// Resync fetches the next nonce for the given address from the node.
+func (n *NonceManager) Resync(ctx context.Context, address ethereum.Address) error {
+	nonce, err := n.client.PendingNonce(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.nonces[address] = nonce
+	return nil
+}
+
+// Skip marks all nonces up to and including the given one as used. It
+// should be used when the nonce is occupied by a transaction which is not
+// known locally.
+func (n *NonceManager) Skip(address ethereum.Address, nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if next, ok := n.nonces[address]; !ok || next <= nonce {
+		n.nonces[address] = nonce + 1
+	}
+}
+
+// Reset forgets the locally reserved nonce for the given address. The next
+// nonce will be fetched from the node.
+func (n *NonceManager) Reset(address ethereum.Address) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.nonces, address)
+}
+
+func isNonceTooLow(err error) bool {
+	return strings.Contains(err.Error(), "nonce too low")
+}
+
+func isReplacementUnderpriced(err error) bool {
+	return strings.Contains(err.Error(), "replacement transaction underpriced")
+}
@@ -0,0 +1,101 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+)
+
+func TestNonceManager(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.Client{}
+	n := NewNonceManager(cli)
+
+	// The nonce should be fetched only once:
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(5), nil).Once()
+	for i := uint64(5); i < 8; i++ {
+		nonce, err := n.Next(ctx, testAddress)
+		require.NoError(t, err)
+		assert.Equal(t, i, nonce)
+	}
+
+	// Skipping nonces lower than the next one has no effect:
+	n.Skip(testAddress, 6)
+	nonce, err := n.Next(ctx, testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), nonce)
+
+	n.Skip(testAddress, 10)
+	nonce, err = n.Next(ctx, testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), nonce)
+
+	// After reset, the nonce should be fetched again:
+	n.Reset(testAddress)
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(9), nil).Once()
+	nonce, err = n.Next(ctx, testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), nonce)
+}
+
+func TestTxManager_send_NonceTooLow(t *testing.T) {
+	m, cli := newTestTxManager(t)
+	tx := &ethereum.Transaction{Address: testContract, PriorityFee: big.NewInt(1), MaxFee: big.NewInt(2)}
+
+	// The local nonce is behind the chain, so the transaction should be
+	// sent again with the synchronized nonce:
+	cli.On("PendingNonce", mock.Anything, testAddress).Return(uint64(7), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return((*ethereum.Hash)(nil), errors.New("nonce too low")).Once()
+	cli.On("PendingNonce", mock.Anything, testAddress).Return(uint64(9), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash1, nil).Once()
+
+	hash, err := m.send(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, testHash1, *hash)
+	assert.Equal(t, uint64(9), tx.Nonce)
+
+	// The nonce is used by an unknown pending transaction, so the next one
+	// should be used:
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return((*ethereum.Hash)(nil), errors.New("replacement transaction underpriced")).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash2, nil).Once()
+
+	tx.Nonce, tx.NonceSet = 0, false
+	hash, err = m.send(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, testHash2, *hash)
+	assert.Equal(t, uint64(11), tx.Nonce)
+
+	// On other errors, the nonce should be synchronized again:
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return((*ethereum.Hash)(nil), errors.New("error")).Once()
+
+	tx.Nonce, tx.NonceSet = 0, false
+	_, err = m.send(context.Background(), tx)
+	require.Error(t, err)
+	cli.On("PendingNonce", mock.Anything, testAddress).Return(uint64(12), nil).Once()
+	nonce, err := m.nonces.Next(context.Background(), testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), nonce)
+}
@@ -0,0 +1,564 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/log"
+)
+
+const LoggerTag = "TX_MANAGER"
+
+const defaultResendAfterBlocks = 5
+const defaultFeeBump = 15
+const defaultInterval = 15 * time.Second
+
+// minFeeBump is the minimum increase of fees in percent required by nodes
+// to replace a pending transaction.
+const minFeeBump = 10
+
+// maxSendAttempts is the maximum number of attempts to send a new
+// transaction if its nonce is already used.
+const maxSendAttempts = 3
+
+// maxFinishedTxs is the number of finished transactions which are kept to
+// allow checking their status.
+const maxFinishedTxs = 1000
+
+// Status describes the state of a transaction.
+type Status int
+
+const (
+	// StatusPending means that the transaction is waiting to be mined.
+	StatusPending Status = iota
+	// StatusMined means that the transaction was mined successfully.
+	StatusMined
+	// StatusReverted means that the transaction was mined but reverted.
+	StatusReverted
+	// StatusDropped means that the transaction nonce was used by another
+	// transaction and none of the sent versions was mined.
+	StatusDropped
+	// StatusStuck means that the transaction is still pending, but it cannot
+	// be resent because its fees cannot be bumped above the maximum fee.
+	// The transaction is still checked until it is mined.
+	StatusStuck
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusMined:
+		return "mined"
+	case StatusReverted:
+		return "reverted"
+	case StatusDropped:
+		return "dropped"
+	case StatusStuck:
+		return "stuck"
+	}
+	return "unknown"
+}
+
+// Tx is a transaction tracked by the TxManager.
+type Tx struct {
+	// Transaction is the most recently sent version of the transaction.
+	Transaction ethereum.Transaction
+	// Hashes is the list of hashes of all sent versions of the transaction.
+	// The last hash belongs to the most recently sent version.
+	Hashes []ethereum.Hash
+	// Status is the current status of the transaction.
+	Status Status
+	// Receipt is the transaction receipt. It is nil until the transaction
+	// is mined.
+	Receipt *ethereum.Receipt
+	// SentAt is the time when the first version of the transaction was sent.
+	SentAt time.Time
+
+	sentBlock uint64 // sentBlock is the block number at the time of the last send.
+}
+
+// TxManager is a wrapper for the ethereum.Client which tracks sent
+// transactions until they are mined. Transactions which are not mined within
+// the given number of blocks are resent with the same nonce and bumped
+// fees. The result of every transaction is reported in logs.
+//
+// All methods except SendTransaction are passed directly to the wrapped
+// client.
+type TxManager struct {
+	ethereum.Client
+
+	ctx    context.Context
+	mu     sync.RWMutex
+	sendMu sync.Mutex
+	doneCh chan struct{}
+
+	signer            ethereum.Signer
+	nonces            *NonceManager
+	resendAfterBlocks uint64
+	feeBump           float64
+	maxFee            *big.Int
+	interval          time.Duration
+	log               log.Logger
+
+	txs      map[ethereum.Hash]*Tx
+	pending  []*Tx
+	finished []*Tx
+}
+
+type Config struct {
+	// Client is an Ethereum client used to send transactions and fetch
+	// receipts.
+	Client ethereum.Client
+	// Signer is the signer of sent transactions. It is used to fetch the
+	// nonce of new transactions.
+	Signer ethereum.Signer
+	// ResendAfterBlocks is the number of blocks after which a transaction
+	// that was not mined is resent. If zero, the default value of 5 blocks
+	// is used.
+	ResendAfterBlocks uint64
+	// FeeBump is the increase of the priority fee and maximum fee in
+	// percent used when a transaction is resent. Nodes reject replacement
+	// transactions with fees bumped less than 10%. If zero, the default
+	// value of 15% is used.
+	FeeBump float64
+	// MaxFee is the hard limit for the maximum fee used by the client. If the
+	// fees of a transaction cannot be bumped enough without exceeding this
+	// limit, the transaction is no longer resent and it is reported as
+	// stuck. If nil, fees are not limited.
+	MaxFee *big.Int
+	// Interval describes how often pending transactions are checked. If
+	// zero, the default value of 15 seconds is used.
+	Interval time.Duration
+	// Logger is a current logger interface used by the TxManager.
+	Logger log.Logger
+}
+
+// NewTxManager returns a new instance of the TxManager.
+func NewTxManager(ctx context.Context, cfg Config) (*TxManager, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	m := &TxManager{
+		Client:            cfg.Client,
+		ctx:               ctx,
+		doneCh:            make(chan struct{}),
+		signer:            cfg.Signer,
+		nonces:            NewNonceManager(cfg.Client),
+		resendAfterBlocks: cfg.ResendAfterBlocks,
+		feeBump:           cfg.FeeBump,
+		maxFee:            cfg.MaxFee,
+		interval:          cfg.Interval,
+		log:               cfg.Logger.WithField("tag", LoggerTag),
+		txs:               make(map[ethereum.Hash]*Tx),
+	}
+	if m.resendAfterBlocks == 0 {
+		m.resendAfterBlocks = defaultResendAfterBlocks
+	}
+	if m.feeBump == 0 {
+		m.feeBump = defaultFeeBump
+	}
+	if m.interval == 0 {
+		m.interval = defaultInterval
+	}
+	return m, nil
+}
+
+// Start starts checking pending transactions.
+func (m *TxManager) Start() error {
+	m.log.Info("Starting")
+
+	go m.contextCancelHandler()
+	go m.checkerLoop()
+	return nil
+}
+
+// Wait waits until the context is canceled.
+func (m *TxManager) Wait() {
+	<-m.doneCh
+}
+
+// SendTransaction implements the ethereum.Client interface. Missing nonce
+// and fees are filled before the transaction is sent, so the transaction
+// can be later resent with the same nonce and bumped fees. Nonces are
+// reserved locally using the NonceManager. Transactions which are already
+// signed are only tracked, they are never resent.
+func (m *TxManager) SendTransaction(ctx context.Context, transaction *ethereum.Transaction) (*ethereum.Hash, error) {
+	var err error
+
+	tx := *transaction
+	tx.Data = make([]byte, len(transaction.Data))
+	copy(tx.Data, transaction.Data)
+
+	if tx.SignedTx == nil {
+		if tx.PriorityFee == nil || tx.MaxFee == nil {
+			priorityFee, maxFee, err := m.Client.SuggestFees(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if tx.PriorityFee == nil {
+				tx.PriorityFee = priorityFee
+			}
+			if tx.MaxFee == nil {
+				tx.MaxFee = maxFee
+			}
+		}
+	}
+	block, err := m.Client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := m.send(ctx, &tx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	t := &Tx{
+		Transaction: tx,
+		Hashes:      []ethereum.Hash{*hash},
+		Status:      StatusPending,
+		SentAt:      time.Now(),
+		sentBlock:   block,
+	}
+	m.txs[*hash] = t
+	m.pending = append(m.pending, t)
+	pendingTransactionsMetric.Set(float64(len(m.pending)))
+	fields := txFields(t)
+	m.mu.Unlock()
+
+	transactionsSentMetric.WithLabelValues(tx.Address.String()).Inc()
+	m.log.
+		WithFields(fields).
+		Info("Transaction sent")
+
+	return hash, nil
+}
+
+// send sends a new transaction. If the transaction has no nonce, the next
+// nonce reserved by the NonceManager is used. If the nonce turns out to be
+// already used, the transaction is sent again with another nonce.
+func (m *TxManager) send(ctx context.Context, tx *ethereum.Transaction) (*ethereum.Hash, error) {
+	if tx.SignedTx != nil || tx.Nonce != 0 || tx.NonceSet {
+		return m.Client.SendTransaction(ctx, tx)
+	}
+
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+
+	address := m.signer.Address()
+	for i := 1; ; i++ {
+		nonce, err := m.nonces.Next(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		tx.Nonce = nonce
+		tx.NonceSet = true
+		hash, err := m.Client.SendTransaction(ctx, tx)
+		if err == nil {
+			return hash, nil
+		}
+		switch {
+		case isNonceTooLow(err):
+			// The local nonce is behind the chain, e.g. because another
+			// transaction was sent from the same account:
+			if rErr := m.nonces.Resync(ctx, address); rErr != nil {
+				m.nonces.Reset(address)
+				return nil, err
+			}
+			m.nonces.Skip(address, nonce)
+		case isReplacementUnderpriced(err):
+			// The nonce is used by a pending transaction which is not known
+			// locally, the next nonce is already reserved, so the
+			// transaction may be simply sent again.
+		default:
+			// The nonce was not used, so the local nonce must be
+			// synchronized again to avoid a gap:
+			m.nonces.Reset(address)
+			return nil, err
+		}
+		if i >= maxSendAttempts {
+			m.nonces.Reset(address)
+			return nil, err
+		}
+		m.log.
+			WithFields(log.Fields{"address": tx.Address.String(), "nonce": nonce}).
+			WithError(err).
+			Warn("Nonce is already used, sending the transaction with another nonce")
+	}
+}
+
+// Transaction returns a copy of the tracked transaction with the given
+// hash. The hash may belong to any sent version of the transaction. The
+// second returned value is false if the transaction is unknown.
+func (m *TxManager) Transaction(hash ethereum.Hash) (Tx, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.txs[hash]
+	if !ok {
+		return Tx{}, false
+	}
+	c := *t
+	c.Hashes = append([]ethereum.Hash{}, t.Hashes...)
+	return c, true
+}
+
+// checkerLoop periodically checks the status of pending transactions.
+func (m *TxManager) checkerLoop() {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkPending()
+		}
+	}
+}
+
+// checkPending checks all pending transactions. Transactions which are
+// mined are removed from the pending list and transactions which are not
+// mined within the configured number of blocks are resent.
+func (m *TxManager) checkPending() {
+	m.mu.RLock()
+	pending := append([]*Tx{}, m.pending...)
+	m.mu.RUnlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	block, err := m.Client.BlockNumber(m.ctx)
+	if err != nil {
+		m.log.
+			WithError(err).
+			Warn("Unable to fetch the block number")
+		return
+	}
+	for _, t := range pending {
+		m.checkTx(t, block)
+	}
+}
+
+func (m *TxManager) checkTx(t *Tx, block uint64) {
+	receipt, err := m.receipt(t)
+	if err != nil {
+		m.log.
+			WithFields(txFields(t)).
+			WithError(err).
+			Warn("Unable to fetch the transaction receipt")
+		return
+	}
+	if receipt != nil {
+		status := StatusMined
+		if !receipt.Success {
+			status = StatusReverted
+		}
+		m.finish(t, status, receipt)
+		return
+	}
+	if t.Transaction.SignedTx != nil || t.Status == StatusStuck || block < t.sentBlock+m.resendAfterBlocks {
+		return
+	}
+	m.resend(t, block)
+}
+
+// resend sends a new version of the transaction with the same nonce and
+// bumped fees. If the fees cannot be bumped enough to replace the previous
+// version without exceeding the maximum fee, the transaction is marked as
+// stuck instead.
+func (m *TxManager) resend(t *Tx, block uint64) {
+	tx := t.Transaction
+	tx.PriorityFee = bumpFee(tx.PriorityFee, m.feeBump)
+	tx.MaxFee = bumpFee(tx.MaxFee, m.feeBump)
+	if m.maxFee != nil && tx.MaxFee != nil && tx.MaxFee.Cmp(m.maxFee) > 0 {
+		tx.MaxFee = new(big.Int).Set(m.maxFee)
+		if tx.PriorityFee != nil && tx.PriorityFee.Cmp(tx.MaxFee) > 0 {
+			tx.PriorityFee = new(big.Int).Set(tx.MaxFee)
+		}
+		if !isReplacement(t.Transaction, tx) {
+			m.stuck(t)
+			return
+		}
+	}
+	hash, err := m.Client.SendTransaction(m.ctx, &tx)
+	if err != nil {
+		if isNonceTooLow(err) {
+			// The nonce was used by another transaction. If that transaction
+			// is not one of the sent versions, the transaction is dropped:
+			receipt, rErr := m.receipt(t)
+			switch {
+			case rErr != nil:
+				return
+			case receipt == nil:
+				m.finish(t, StatusDropped, nil)
+			case receipt.Success:
+				m.finish(t, StatusMined, receipt)
+			default:
+				m.finish(t, StatusReverted, receipt)
+			}
+			return
+		}
+		if isReplacementUnderpriced(err) {
+			// The fees have to be bumped even more on the next attempt:
+			m.mu.Lock()
+			t.Transaction = tx
+			m.mu.Unlock()
+		}
+		m.log.
+			WithFields(txFields(t)).
+			WithError(err).
+			Warn("Unable to resend the transaction")
+		return
+	}
+
+	m.mu.Lock()
+	t.Transaction = tx
+	t.Hashes = append(t.Hashes, *hash)
+	t.sentBlock = block
+	m.txs[*hash] = t
+	m.mu.Unlock()
+
+	transactionsResentMetric.WithLabelValues(tx.Address.String()).Inc()
+	m.log.
+		WithFields(txFields(t)).
+		Warn("Transaction was not mined in time, resent with bumped fees")
+}
+
+// stuck marks the transaction as stuck, so it is no longer resent.
+func (m *TxManager) stuck(t *Tx) {
+	m.mu.Lock()
+	t.Status = StatusStuck
+	m.mu.Unlock()
+
+	transactionsStuckMetric.WithLabelValues(t.Transaction.Address.String()).Inc()
+	m.log.
+		WithFields(txFields(t)).
+		Error("Transaction is stuck, the fees cannot be bumped above the maximum fee")
+}
+
+// receipt returns the receipt for any sent version of the transaction or
+// nil if none of them is mined.
+func (m *TxManager) receipt(t *Tx) (*ethereum.Receipt, error) {
+	for _, hash := range t.Hashes {
+		receipt, err := m.Client.TransactionReceipt(m.ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+	}
+	return nil, nil
+}
+
+// finish removes the transaction from the pending list and reports its
+// status.
+func (m *TxManager) finish(t *Tx, status Status, receipt *ethereum.Receipt) {
+	m.mu.Lock()
+	t.Status = status
+	t.Receipt = receipt
+	for i, p := range m.pending {
+		if p == t {
+			m.pending = append(m.pending[:i], m.pending[i+1:]...)
+			break
+		}
+	}
+	m.finished = append(m.finished, t)
+	if len(m.finished) > maxFinishedTxs {
+		for _, hash := range m.finished[0].Hashes {
+			delete(m.txs, hash)
+		}
+		m.finished = m.finished[1:]
+	}
+	pendingTransactionsMetric.Set(float64(len(m.pending)))
+	m.mu.Unlock()
+
+	transactionsFinishedMetric.WithLabelValues(t.Transaction.Address.String(), status.String()).Inc()
+	switch status {
+	case StatusMined:
+		m.log.
+			WithFields(txFields(t)).
+			Info("Transaction mined")
+	case StatusReverted:
+		m.log.
+			WithFields(txFields(t)).
+			Error("Transaction reverted")
+	case StatusDropped:
+		m.log.
+			WithFields(txFields(t)).
+			Warn("Transaction dropped, the nonce was used by another transaction")
+	}
+}
+
+func (m *TxManager) contextCancelHandler() {
+	defer func() { close(m.doneCh) }()
+	defer m.log.Info("Stopped")
+	<-m.ctx.Done()
+}
+
+// bumpFee increases the fee by the given percent. The returned fee is
+// always greater than the given one.
+func bumpFee(fee *big.Int, percent float64) *big.Int {
+	if fee == nil {
+		return nil
+	}
+	b := new(big.Int).Mul(fee, big.NewInt(int64(10000+percent*100)))
+	b.Div(b, big.NewInt(10000))
+	if b.Cmp(fee) <= 0 {
+		b.Add(fee, big.NewInt(1))
+	}
+	return b
+}
+
+// isReplacement returns true if fees of the new version of the transaction
+// are bumped enough to replace the previous version.
+func isReplacement(prev, next ethereum.Transaction) bool {
+	if prev.PriorityFee != nil && next.PriorityFee.Cmp(bumpFee(prev.PriorityFee, minFeeBump)) < 0 {
+		return false
+	}
+	if prev.MaxFee != nil && next.MaxFee.Cmp(bumpFee(prev.MaxFee, minFeeBump)) < 0 {
+		return false
+	}
+	return true
+}
+
+func txFields(t *Tx) log.Fields {
+	f := log.Fields{
+		"address": t.Transaction.Address.String(),
+		"nonce":   t.Transaction.Nonce,
+		"tx":      t.Hashes[len(t.Hashes)-1].String(),
+		"status":  t.Status.String(),
+	}
+	if t.Transaction.PriorityFee != nil {
+		f["priorityFee"] = t.Transaction.PriorityFee.String()
+	}
+	if t.Transaction.MaxFee != nil {
+		f["maxFee"] = t.Transaction.MaxFee.String()
+	}
+	if t.Receipt != nil {
+		f["block"] = t.Receipt.BlockNumber
+		f["gasUsed"] = t.Receipt.GasUsed
+	}
+	return f
+}
@@ -0,0 +1,195 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+var (
+	testAddress  = ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	testContract = ethereum.HexToAddress("0x0e30f0fc91fdbc4594b1e2e5d64e6f1f94cab23d")
+	testHash1    = ethereum.HexToHash("0x01")
+	testHash2    = ethereum.HexToHash("0x02")
+)
+
+func newTestTxManager(t *testing.T) (*TxManager, *mocks.Client) {
+	cli := &mocks.Client{}
+	sig := &mocks.Signer{}
+	sig.On("Address").Return(testAddress)
+	m, err := NewTxManager(context.Background(), Config{
+		Client:            cli,
+		Signer:            sig,
+		ResendAfterBlocks: 2,
+		FeeBump:           20,
+		Logger:            null.New(),
+	})
+	require.NoError(t, err)
+	return m, cli
+}
+
+func TestTxManager_Resend(t *testing.T) {
+	m, cli := newTestTxManager(t)
+
+	cli.On("PendingNonce", mock.Anything, testAddress).Return(uint64(7), nil).Once()
+	cli.On("SuggestFees", mock.Anything).Return(big.NewInt(10), big.NewInt(100), nil).Once()
+	cli.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash1, nil).Once()
+
+	hash, err := m.SendTransaction(context.Background(), &ethereum.Transaction{Address: testContract})
+	require.NoError(t, err)
+	assert.Equal(t, testHash1, *hash)
+
+	// The transaction is not mined, but it's too early to resend it:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(101), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash1).Return((*ethereum.Receipt)(nil), nil).Once()
+	m.checkPending()
+	cli.AssertNumberOfCalls(t, "SendTransaction", 1)
+
+	// After two blocks the transaction should be resent with the same nonce
+	// and bumped fees:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(102), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash1).Return((*ethereum.Receipt)(nil), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash2, nil).Once()
+	m.checkPending()
+	resent := cli.Calls[len(cli.Calls)-1].Arguments.Get(1).(*ethereum.Transaction)
+	assert.Equal(t, uint64(7), resent.Nonce)
+	assert.Equal(t, big.NewInt(12), resent.PriorityFee)
+	assert.Equal(t, big.NewInt(120), resent.MaxFee)
+
+	// The first version of the transaction was mined but reverted:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(103), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash1).Return(&ethereum.Receipt{TxHash: testHash1}, nil).Once()
+	m.checkPending()
+
+	tx, ok := m.Transaction(testHash2)
+	require.True(t, ok)
+	assert.Equal(t, StatusReverted, tx.Status)
+	assert.Equal(t, []ethereum.Hash{testHash1, testHash2}, tx.Hashes)
+	assert.Empty(t, m.pending)
+}
+
+func TestTxManager_ResendZeroNonce(t *testing.T) {
+	m, cli := newTestTxManager(t)
+
+	cli.On("PendingNonce", mock.Anything, testAddress).Return(uint64(0), nil).Once()
+	cli.On("SuggestFees", mock.Anything).Return(big.NewInt(10), big.NewInt(100), nil).Once()
+	cli.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash1, nil).Once()
+
+	_, err := m.SendTransaction(context.Background(), &ethereum.Transaction{Address: testContract})
+	require.NoError(t, err)
+	sent := cli.Calls[len(cli.Calls)-1].Arguments.Get(1).(*ethereum.Transaction)
+	assert.Equal(t, uint64(0), sent.Nonce)
+	assert.True(t, sent.NonceSet)
+
+	// The zero nonce must be kept when the transaction is resent:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(102), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash1).Return((*ethereum.Receipt)(nil), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash2, nil).Once()
+	m.checkPending()
+	resent := cli.Calls[len(cli.Calls)-1].Arguments.Get(1).(*ethereum.Transaction)
+	assert.Equal(t, uint64(0), resent.Nonce)
+	assert.True(t, resent.NonceSet)
+	cli.AssertNumberOfCalls(t, "PendingNonce", 1)
+}
+
+func TestTxManager_Stuck(t *testing.T) {
+	m, cli := newTestTxManager(t)
+	m.maxFee = big.NewInt(110)
+
+	cli.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash1, nil).Once()
+
+	_, err := m.SendTransaction(context.Background(), &ethereum.Transaction{
+		Address:     testContract,
+		Nonce:       7,
+		PriorityFee: big.NewInt(10),
+		MaxFee:      big.NewInt(100),
+	})
+	require.NoError(t, err)
+
+	// The maximum fee is limited, but it is still enough to replace
+	// the transaction:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(102), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash1).Return((*ethereum.Receipt)(nil), nil)
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash2, nil).Once()
+	m.checkPending()
+	resent := cli.Calls[len(cli.Calls)-1].Arguments.Get(1).(*ethereum.Transaction)
+	assert.Equal(t, big.NewInt(12), resent.PriorityFee)
+	assert.Equal(t, big.NewInt(110), resent.MaxFee)
+
+	// The fees cannot be bumped anymore, so the transaction is stuck:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(104), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash2).Return((*ethereum.Receipt)(nil), nil).Once()
+	m.checkPending()
+	cli.AssertNumberOfCalls(t, "SendTransaction", 2)
+	tx, ok := m.Transaction(testHash2)
+	require.True(t, ok)
+	assert.Equal(t, StatusStuck, tx.Status)
+
+	// Stuck transactions are not resent, but they are still checked:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(110), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash2).Return(&ethereum.Receipt{TxHash: testHash2, Success: true}, nil).Once()
+	m.checkPending()
+	cli.AssertNumberOfCalls(t, "SendTransaction", 2)
+	tx, ok = m.Transaction(testHash2)
+	require.True(t, ok)
+	assert.Equal(t, StatusMined, tx.Status)
+	assert.Empty(t, m.pending)
+}
+
+func TestTxManager_Dropped(t *testing.T) {
+	m, cli := newTestTxManager(t)
+
+	cli.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&testHash1, nil).Once()
+
+	_, err := m.SendTransaction(context.Background(), &ethereum.Transaction{
+		Address:     testContract,
+		Nonce:       7,
+		PriorityFee: big.NewInt(10),
+		MaxFee:      big.NewInt(100),
+	})
+	require.NoError(t, err)
+
+	// The nonce was used by another transaction:
+	cli.On("BlockNumber", mock.Anything).Return(uint64(105), nil).Once()
+	cli.On("TransactionReceipt", mock.Anything, testHash1).Return((*ethereum.Receipt)(nil), nil)
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return((*ethereum.Hash)(nil), errors.New("nonce too low")).Once()
+	m.checkPending()
+
+	tx, ok := m.Transaction(testHash1)
+	require.True(t, ok)
+	assert.Equal(t, StatusDropped, tx.Status)
+}
+
+func Test_bumpFee(t *testing.T) {
+	assert.Equal(t, big.NewInt(115), bumpFee(big.NewInt(100), 15))
+	assert.Equal(t, big.NewInt(1), bumpFee(big.NewInt(0), 15))
+	assert.Nil(t, bumpFee(nil, 15))
+}
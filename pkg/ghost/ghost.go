@@ -19,14 +19,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/makerdao/oracle-suite/internal/gofer/marshal"
+	"github.com/makerdao/oracle-suite/pkg/datastore"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 	"github.com/makerdao/oracle-suite/pkg/log"
 	"github.com/makerdao/oracle-suite/pkg/oracle"
+	"github.com/makerdao/oracle-suite/pkg/starkex"
 	"github.com/makerdao/oracle-suite/pkg/transport"
 	"github.com/makerdao/oracle-suite/pkg/transport/messages"
 )
@@ -41,17 +45,68 @@ func (e ErrUnableToFindAsset) Error() string {
 	return fmt.Sprintf("unable to find the %s in Gofer price models", e.AssetName)
 }
 
+type ErrPriceTooOld struct {
+	Pair   string
+	Age    time.Time
+	MaxAge time.Duration
+}
+
+func (e ErrPriceTooOld) Error() string {
+	return fmt.Sprintf(
+		"the price for the %s is too old, it was fetched at %s but the maximum age is %s",
+		e.Pair,
+		e.Age.UTC().Format(time.RFC3339),
+		e.MaxAge,
+	)
+}
+
+type ErrPriceOlderThanLast struct {
+	Pair    string
+	Age     time.Time
+	LastAge time.Time
+}
+
+func (e ErrPriceOlderThanLast) Error() string {
+	return fmt.Sprintf(
+		"the price for the %s fetched at %s is older than the last signed price from %s",
+		e.Pair,
+		e.Age.UTC().Format(time.RFC3339),
+		e.LastAge.UTC().Format(time.RFC3339),
+	)
+}
+
+type ErrFetchTimeout struct {
+	Timeout time.Duration
+}
+
+func (e ErrFetchTimeout) Error() string {
+	return fmt.Sprintf("unable to fetch prices within %s", e.Timeout)
+}
+
 type Ghost struct {
 	ctx    context.Context
 	doneCh chan struct{}
 
-	gofer      gofer.Gofer
-	signer     ethereum.Signer
-	transport  transport.Transport
-	interval   time.Duration
-	pairs      []string
-	goferPairs map[gofer.Pair]string
-	log        log.Logger
+	gofer        gofer.Gofer
+	signer       ethereum.Signer
+	starkKey     *starkex.PrivateKey
+	transport    transport.Transport
+	datastore    datastore.Datastore
+	state        StateStore
+	interval     time.Duration
+	fetchTimeout time.Duration
+	pairs        []string
+	policies     map[string]Policy
+	goferPairs   map[gofer.Pair]string
+	signSem      chan struct{}
+	bundle       bool
+	log          log.Logger
+
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	last     map[string]*broadcastState
+	signed   map[string]time.Time
+	withheld map[string]*withheldState
 }
 
 type Config struct {
@@ -61,11 +116,36 @@ type Config struct {
 	// Signer is an instance of the ethereum.Signer which will be used to
 	// sign prices.
 	Signer ethereum.Signer
+	// StarkKey is an optional key used to add the StarkEx signature to
+	// prices.
+	StarkKey *starkex.PrivateKey
 	// Transport is a implementation of transport used to send prices to
 	// relayers.
 	Transport transport.Transport
-	// Interval describes how often we should send prices to the network.
+	// Datastore is an optional datastore with prices sent by other feeders.
+	// It is used to compare prices with the peers' median before they are
+	// signed. The datastore must be started by the caller.
+	Datastore datastore.Datastore
+	// State is an optional store used to persist the last signed and
+	// the last broadcast price for every pair across restarts.
+	State StateStore
+	// Interval describes how often prices are checked and, for pairs without
+	// a policy, how often they are sent to the network. It may be overridden
+	// for a pair by its policy.
 	Interval time.Duration
+	// Policies is a list of broadcasting policies for pairs. Pairs without
+	// a policy are broadcast on every interval.
+	Policies map[string]Policy
+	// FetchTimeout is the maximum time to wait for prices from the Gofer in
+	// a single broadcast cycle. If zero, the shortest interval of all pairs
+	// is used.
+	FetchTimeout time.Duration
+	// SigningConcurrency is the maximum number of prices signed at the same
+	// time. If zero, the number of CPUs is used.
+	SigningConcurrency int
+	// Bundle enables sending all prices signed in a single cycle as one
+	// price bundle message instead of separate price messages.
+	Bundle bool
 	// Logger is a current logger interface used by the Ghost. The Logger
 	// helps to monitor asynchronous processes.
 	Logger log.Logger
@@ -77,16 +157,30 @@ func NewGhost(ctx context.Context, cfg Config) (*Ghost, error) {
 	if ctx == nil {
 		return nil, errors.New("context must not be nil")
 	}
+	signingConcurrency := cfg.SigningConcurrency
+	if signingConcurrency <= 0 {
+		signingConcurrency = runtime.NumCPU()
+	}
 	g := &Ghost{
-		ctx:        ctx,
-		doneCh:     make(chan struct{}),
-		gofer:      cfg.Gofer,
-		signer:     cfg.Signer,
-		transport:  cfg.Transport,
-		interval:   cfg.Interval,
-		pairs:      cfg.Pairs,
-		goferPairs: make(map[gofer.Pair]string),
-		log:        cfg.Logger.WithField("tag", LoggerTag),
+		ctx:          ctx,
+		doneCh:       make(chan struct{}),
+		gofer:        cfg.Gofer,
+		signer:       cfg.Signer,
+		starkKey:     cfg.StarkKey,
+		transport:    cfg.Transport,
+		datastore:    cfg.Datastore,
+		state:        cfg.State,
+		interval:     cfg.Interval,
+		fetchTimeout: cfg.FetchTimeout,
+		pairs:        cfg.Pairs,
+		policies:     cfg.Policies,
+		goferPairs:   make(map[gofer.Pair]string),
+		log:          cfg.Logger.WithField("tag", LoggerTag),
+		signSem:      make(chan struct{}, signingConcurrency),
+		bundle:       cfg.Bundle,
+		last:         make(map[string]*broadcastState),
+		signed:       make(map[string]time.Time),
+		withheld:     make(map[string]*withheldState),
 	}
 	return g, nil
 }
@@ -115,7 +209,12 @@ func (g *Ghost) Start() error {
 		}
 	}
 
-	err := g.broadcasterLoop()
+	err := g.loadState()
+	if err != nil {
+		return err
+	}
+
+	err = g.broadcasterLoop()
 	if err != nil {
 		return err
 	}
@@ -128,31 +227,235 @@ func (g *Ghost) Wait() {
 	<-g.doneCh
 }
 
-// broadcast sends price for single pair to the network. This method uses
-// current price from the Gofer so it must be updated beforehand.
-func (g *Ghost) broadcast(goferPair gofer.Pair) error {
-	var err error
+// loadState restores the last signed and the last broadcast prices from
+// the state store.
+func (g *Ghost) loadState() error {
+	if g.state == nil {
+		return nil
+	}
+	state, err := g.state.Load()
+	if err != nil {
+		return fmt.Errorf("unable to load the state: %w", err)
+	}
 
-	pair := g.goferPairs[goferPair]
-	tick, err := g.gofer.Price(goferPair)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, pair := range g.pairs {
+		if e, ok := state[pair]; ok && e.Price != nil && e.Price.Val != nil {
+			g.signed[pair] = e.Price.Age
+			g.log.
+				WithFields(log.Fields{
+					"assetPair": pair,
+					"val":       e.Price.Val.String(),
+					"age":       e.Price.Age.UTC().Format(time.RFC3339),
+				}).
+				Info("Last signed price restored")
+		}
+		if e, ok := state[pair]; ok && e.Broadcast != nil && e.Broadcast.Val != nil {
+			g.last[pair] = &broadcastState{
+				price: e.Broadcast.Float64Price(),
+				age:   e.Broadcast.Age,
+				time:  e.BroadcastTime,
+			}
+		}
+	}
+	return nil
+}
+
+type schedule struct {
+	pair     gofer.Pair
+	interval time.Duration
+	next     time.Time
+}
+
+// broadcasterLoop creates an asynchronous loop which checks prices for every
+// pair at its own interval. Pairs which are due at the same time are
+// handled together in a single broadcast cycle.
+func (g *Ghost) broadcasterLoop() error {
+	var ss []*schedule
+	now := time.Now()
+	for goferPair, pair := range g.goferPairs {
+		interval := g.interval
+		if p := g.policies[pair]; p.Interval > 0 {
+			interval = p.Interval
+		}
+		if interval == 0 {
+			continue
+		}
+		ss = append(ss, &schedule{pair: goferPair, interval: interval, next: now.Add(interval)})
+	}
+	if len(ss) == 0 {
+		return nil
+	}
+	if g.fetchTimeout == 0 {
+		g.fetchTimeout = shortestInterval(ss)
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		timer := time.NewTimer(time.Until(nextSchedule(ss)))
+		defer timer.Stop()
+		for {
+			select {
+			case <-g.ctx.Done():
+				return
+			case <-timer.C:
+				g.broadcastCycle(duePairs(ss, time.Now()))
+				timer.Reset(time.Until(nextSchedule(ss)))
+			}
+		}
+	}()
+
+	return nil
+}
+
+// broadcastCycle fetches prices for given pairs in a single batch, then
+// signs them concurrently and finally sends them to the network.
+func (g *Ghost) broadcastCycle(pairs []gofer.Pair) {
+	// Fetch phase:
+	fetchStart := time.Now()
+	ticks, err := g.fetchPrices(pairs)
+	fetchDuration := time.Since(fetchStart)
 	if err != nil {
-		return err
+		for _, goferPair := range pairs {
+			g.handleBroadcastResult(goferPair, false, err)
+		}
+		return
+	}
+
+	// Signing phase:
+	//
+	// Signing may be slow, especially with high KDF so this is why
+	// we're using goroutines here.
+	signStart := time.Now()
+	prices := make([]*oracle.Price, len(pairs))
+	errs := make([]error, len(pairs))
+	wg := sync.WaitGroup{}
+	for i, goferPair := range pairs {
+		prices[i], errs[i] = g.createPrice(goferPair, ticks[goferPair])
+		if prices[i] == nil {
+			continue
+		}
+		wg.Add(1)
+		g.signSem <- struct{}{}
+		go func(i int) {
+			defer func() { <-g.signSem }()
+			defer wg.Done()
+			errs[i] = g.sign(prices[i])
+		}(i)
+	}
+	wg.Wait()
+	for i := range pairs {
+		if prices[i] != nil && errs[i] == nil {
+			g.updateState(prices[i])
+		}
+	}
+	signDuration := time.Since(signStart)
+
+	// Broadcasting phase:
+	broadcastStart := time.Now()
+	if g.bundle {
+		g.broadcastBundle(pairs, prices, ticks, errs)
+	} else {
+		for i, goferPair := range pairs {
+			if prices[i] == nil || errs[i] != nil {
+				g.handleBroadcastResult(goferPair, false, errs[i])
+				continue
+			}
+			err := g.broadcast(prices[i], ticks[goferPair])
+			g.handleBroadcastResult(goferPair, err == nil, err)
+		}
+	}
+	broadcastDuration := time.Since(broadcastStart)
+
+	g.log.
+		WithFields(log.Fields{
+			"pairs":             len(pairs),
+			"fetchDuration":     fetchDuration.String(),
+			"signDuration":      signDuration.String(),
+			"broadcastDuration": broadcastDuration.String(),
+		}).
+		Debug("Broadcast cycle finished")
+}
+
+// fetchPrices fetches prices for given pairs from the Gofer. If the Gofer
+// does not respond within the fetch timeout, an error is returned, so
+// a slow price source does not block the broadcaster loop. The abandoned
+// request is left to finish in the background.
+func (g *Ghost) fetchPrices(pairs []gofer.Pair) (map[gofer.Pair]*gofer.Price, error) {
+	if g.fetchTimeout <= 0 {
+		return g.gofer.Prices(pairs...)
+	}
+	type result struct {
+		ticks map[gofer.Pair]*gofer.Price
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		ticks, err := g.gofer.Prices(pairs...)
+		ch <- result{ticks: ticks, err: err}
+	}()
+	timer := time.NewTimer(g.fetchTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-ch:
+		return r.ticks, r.err
+	case <-timer.C:
+		return nil, ErrFetchTimeout{Timeout: g.fetchTimeout}
+	case <-g.ctx.Done():
+		return nil, g.ctx.Err()
+	}
+}
+
+// createPrice creates an unsigned price for a pair if it is required by
+// the pair's policy. It returns nil if the price should not be sent.
+func (g *Ghost) createPrice(goferPair gofer.Pair, tick *gofer.Price) (*oracle.Price, error) {
+	pair := g.goferPairs[goferPair]
+	if tick == nil {
+		return nil, fmt.Errorf("missing price for the %s pair", goferPair)
 	}
 	if tick.Error != "" {
-		return errors.New(tick.Error)
+		return nil, errors.New(tick.Error)
+	}
+	if maxAge := g.policies[pair].MaxSourceAge; maxAge > 0 && time.Since(tick.Time) > maxAge {
+		return nil, ErrPriceTooOld{Pair: pair, Age: tick.Time, MaxAge: maxAge}
+	}
+
+	now := time.Now()
+	g.mu.Lock()
+	last := g.last[pair]
+	signed, ok := g.signed[pair]
+	g.mu.Unlock()
+	if ok && tick.Time.Before(signed) {
+		return nil, ErrPriceOlderThanLast{Pair: pair, Age: tick.Time, LastAge: signed}
+	}
+	if !g.policies[pair].shouldBroadcast(last, tick.Price, now) {
+		return nil, nil
+	}
+	if err := g.selfCheck(pair, last, tick.Price, now); err != nil {
+		return nil, err
 	}
 
-	// Create price:
 	price := &oracle.Price{Wat: pair, Age: tick.Time}
 	price.SetFloat64Price(tick.Price)
+	return price, nil
+}
 
-	// Sign price:
-	err = price.Sign(g.signer)
-	if err != nil {
+// sign signs the price using the Ethereum signer and, if the stark key is
+// set, adds the StarkEx signature.
+func (g *Ghost) sign(price *oracle.Price) error {
+	if err := price.Sign(g.signer); err != nil {
 		return err
 	}
+	if g.starkKey != nil {
+		return price.SignStark(g.starkKey)
+	}
+	return nil
+}
 
-	// Broadcast price to P2P network:
+// broadcast sends a signed price to the network.
+func (g *Ghost) broadcast(price *oracle.Price, tick *gofer.Price) error {
 	message, err := createPriceMessage(price, tick)
 	if err != nil {
 		return err
@@ -161,61 +464,153 @@ func (g *Ghost) broadcast(goferPair gofer.Pair) error {
 	if err != nil {
 		return err
 	}
+	g.updateLast(price, tick)
+	return nil
+}
+
+// broadcastBundle sends all signed prices as a single price bundle.
+func (g *Ghost) broadcastBundle(
+	pairs []gofer.Pair,
+	prices []*oracle.Price,
+	ticks map[gofer.Pair]*gofer.Price,
+	errs []error,
+) {
+
+	var idx []int
+	bundle := &messages.PriceBundle{}
+	for i, goferPair := range pairs {
+		if prices[i] == nil || errs[i] != nil {
+			g.handleBroadcastResult(goferPair, false, errs[i])
+			continue
+		}
+		idx = append(idx, i)
+		bundle.Prices = append(bundle.Prices, prices[i])
+	}
+	if len(idx) == 0 {
+		return
+	}
+	err := bundle.Sign(g.signer)
+	if err == nil {
+		err = g.transport.Broadcast(messages.PriceBundleMessageName, bundle)
+	}
+	for _, i := range idx {
+		if err == nil {
+			g.updateLast(prices[i], ticks[pairs[i]])
+		}
+		g.handleBroadcastResult(pairs[i], err == nil, err)
+	}
+}
+
+// updateLast stores the price as the last broadcast price for its pair and
+// saves it in the state store.
+func (g *Ghost) updateLast(price *oracle.Price, tick *gofer.Price) {
+	now := time.Now()
+	g.mu.Lock()
+	g.last[price.Wat] = &broadcastState{price: tick.Price, age: price.Age, time: now}
+	g.mu.Unlock()
 
-	return err
+	if g.state != nil {
+		if err := g.state.SaveBroadcast(price, now); err != nil {
+			g.log.
+				WithFields(log.Fields{"assetPair": price.Wat}).
+				WithError(err).
+				Warn("Unable to save the state")
+		}
+	}
 }
 
-// broadcasterLoop creates a asynchronous loop which fetches prices from exchanges and then
-// sends them to the network at a specified interval.
-func (g *Ghost) broadcasterLoop() error {
-	if g.interval == 0 {
-		return nil
+// updateState stores the price as the last signed price for its pair and
+// saves it in the state store.
+func (g *Ghost) updateState(price *oracle.Price) {
+	now := time.Now()
+	g.mu.Lock()
+	g.signed[price.Wat] = price.Age
+	g.mu.Unlock()
+
+	if g.state != nil {
+		if err := g.state.SaveSigned(price, now); err != nil {
+			g.log.
+				WithFields(log.Fields{"assetPair": price.Wat}).
+				WithError(err).
+				Warn("Unable to save the state")
+		}
 	}
+}
 
-	ticker := time.NewTicker(g.interval)
-	wg := sync.WaitGroup{}
-	go func() {
-		for {
-			select {
-			case <-g.doneCh:
-				ticker.Stop()
-				return
-			case <-ticker.C:
-				// TODO: fetch all prices before broadcast is called
-
-				// Send prices to the network:
-				//
-				// Signing may be slow, especially with high KDF so this is why
-				// we're using goroutines here.
-				wg.Add(1)
-				go func() {
-					for assetPair := range g.goferPairs {
-						err := g.broadcast(assetPair)
-						if err != nil {
-							g.log.
-								WithFields(log.Fields{"assetPair": assetPair}).
-								WithError(err).
-								Warn("Unable to broadcast price")
-						} else {
-							g.log.
-								WithFields(log.Fields{"assetPair": assetPair}).
-								Info("Price broadcast")
-						}
-					}
-					wg.Done()
-				}()
-			}
-			wg.Wait()
+func (g *Ghost) handleBroadcastResult(goferPair gofer.Pair, sent bool, err error) {
+	var errDeviation ErrPriceDeviation
+	switch {
+	case errors.As(err, &errDeviation):
+		pricesWithheldMetric.WithLabelValues(g.goferPairs[goferPair], errDeviation.Reference).Inc()
+		g.log.
+			WithFields(log.Fields{"assetPair": goferPair}).
+			WithError(err).
+			Error("Price withheld")
+	case err != nil:
+		broadcastErrorsMetric.WithLabelValues(g.goferPairs[goferPair]).Inc()
+		g.log.
+			WithFields(log.Fields{"assetPair": goferPair}).
+			WithError(err).
+			Warn("Unable to broadcast price")
+	case !sent:
+		g.log.
+			WithFields(log.Fields{"assetPair": goferPair}).
+			Debug("Price broadcast skipped")
+	default:
+		broadcastsMetric.WithLabelValues(g.goferPairs[goferPair]).Inc()
+		g.log.
+			WithFields(log.Fields{"assetPair": goferPair}).
+			Info("Price broadcast")
+	}
+}
+
+// nextSchedule returns the earliest time at which any pair is due.
+func nextSchedule(ss []*schedule) time.Time {
+	var next time.Time
+	for i, s := range ss {
+		if i == 0 || s.next.Before(next) {
+			next = s.next
 		}
-	}()
+	}
+	return next
+}
 
-	return nil
+// shortestInterval returns the shortest interval of all schedules.
+func shortestInterval(ss []*schedule) time.Duration {
+	var interval time.Duration
+	for i, s := range ss {
+		if i == 0 || s.interval < interval {
+			interval = s.interval
+		}
+	}
+	return interval
+}
+
+// duePairs returns sorted pairs which are due at the given time and moves
+// their schedules to the next interval.
+func duePairs(ss []*schedule, now time.Time) []gofer.Pair {
+	var pairs []gofer.Pair
+	for _, s := range ss {
+		if s.next.After(now) {
+			continue
+		}
+		pairs = append(pairs, s.pair)
+		for !s.next.After(now) {
+			s.next = s.next.Add(s.interval)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].String() < pairs[j].String()
+	})
+	return pairs
 }
 
 func (g *Ghost) contextCancelHandler() {
 	defer func() { close(g.doneCh) }()
 	defer g.log.Info("Stopped")
 	<-g.ctx.Done()
+
+	g.wg.Wait()
 }
 
 func createPriceMessage(op *oracle.Price, gp *gofer.Price) (*messages.Price, error) {
@@ -0,0 +1,203 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	ethereumMocks "github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	goferMocks "github.com/makerdao/oracle-suite/pkg/gofer/mocks"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+	"github.com/makerdao/oracle-suite/pkg/starkex"
+	"github.com/makerdao/oracle-suite/pkg/transport"
+	"github.com/makerdao/oracle-suite/pkg/transport/local"
+	"github.com/makerdao/oracle-suite/pkg/transport/messages"
+)
+
+var (
+	testAB = gofer.Pair{Base: "AAA", Quote: "BBB"}
+	testXY = gofer.Pair{Base: "XXX", Quote: "YYY"}
+)
+
+func newTestGhost(ctx context.Context, t *testing.T, cfg Config) (*Ghost, *goferMocks.Gofer, *local.Local) {
+	gof := &goferMocks.Gofer{}
+	gof.On("Pairs").Return([]gofer.Pair{testAB, testXY}, nil)
+
+	sig := &ethereumMocks.Signer{}
+	sig.On("Signature", mock.Anything).Return(ethereum.Signature{}, nil)
+
+	tra := local.New(ctx, 10, map[string]transport.Message{
+		messages.PriceMessageName:       (*messages.Price)(nil),
+		messages.PriceBundleMessageName: (*messages.PriceBundle)(nil),
+	})
+	require.NoError(t, tra.Start())
+
+	cfg.Gofer = gof
+	cfg.Signer = sig
+	cfg.Transport = tra
+	cfg.Logger = null.New()
+	g, err := NewGhost(ctx, cfg)
+	require.NoError(t, err)
+	return g, gof, tra
+}
+
+func TestGhost_PairIntervals(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	// Only the AAABBB pair has an interval, so the XXXYYY should never be
+	// broadcast:
+	g, gof, tra := newTestGhost(ctx, t, Config{
+		Pairs:    []string{"AAABBB", "XXXYYY"},
+		Policies: map[string]Policy{"AAABBB": {Interval: 10 * time.Millisecond}},
+	})
+	gof.On("Prices", testAB).Return(map[gofer.Pair]*gofer.Price{
+		testAB: {Pair: testAB, Price: 1, Time: time.Now()},
+	}, nil)
+	require.NoError(t, g.Start())
+
+	for i := 0; i < 3; i++ {
+		select {
+		case msg := <-tra.Messages(messages.PriceMessageName):
+			require.NoError(t, msg.Error)
+			assert.Equal(t, "AAABBB", msg.Message.(*messages.Price).Price.Wat)
+		case <-time.After(time.Second):
+			require.Fail(t, "price was not broadcast")
+		}
+	}
+	gof.AssertNotCalled(t, "Prices", testXY)
+
+	ctxCancel()
+	g.Wait()
+}
+
+func TestGhost_broadcastCycle(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	g, gof, tra := newTestGhost(ctx, t, Config{
+		Pairs:              []string{"AAABBB", "XXXYYY"},
+		Policies:           map[string]Policy{"XXXYYY": {MaxSourceAge: time.Minute}},
+		SigningConcurrency: 2,
+	})
+	gof.On("Prices", testAB, testXY).Return(map[gofer.Pair]*gofer.Price{
+		testAB: {Pair: testAB, Price: 1, Time: time.Now()},
+		testXY: {Pair: testXY, Price: 1, Time: time.Now().Add(-2 * time.Minute)},
+	}, nil).Once()
+	require.NoError(t, g.Start())
+
+	// The XXXYYY price is too old, so only the AAABBB should be sent:
+	g.broadcastCycle([]gofer.Pair{testAB, testXY})
+	msg := <-tra.Messages(messages.PriceMessageName)
+	require.NoError(t, msg.Error)
+	assert.Equal(t, "AAABBB", msg.Message.(*messages.Price).Price.Wat)
+	gof.AssertExpectations(t)
+
+	_, err := g.createPrice(testXY, &gofer.Price{Pair: testXY, Price: 1, Time: time.Now().Add(-2 * time.Minute)})
+	assert.True(t, errors.As(err, &ErrPriceTooOld{}))
+}
+
+func TestGhost_StarkSignature(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	key, err := starkex.DerivePrivateKey([]byte("seed"))
+	require.NoError(t, err)
+
+	g, gof, tra := newTestGhost(ctx, t, Config{
+		Pairs:    []string{"AAABBB"},
+		StarkKey: key,
+	})
+	gof.On("Prices", testAB).Return(map[gofer.Pair]*gofer.Price{
+		testAB: {Pair: testAB, Price: 1, Time: time.Now()},
+	}, nil).Once()
+	require.NoError(t, g.Start())
+
+	g.broadcastCycle([]gofer.Pair{testAB})
+	msg := <-tra.Messages(messages.PriceMessageName)
+	require.NoError(t, msg.Error)
+	price := msg.Message.(*messages.Price).Price
+	assert.Equal(t, key.PublicKey().Bytes(), price.StarkPK)
+	assert.NoError(t, price.VerifyStark(key.PublicKey()))
+}
+
+func TestGhost_Bundle(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	g, gof, tra := newTestGhost(ctx, t, Config{
+		Pairs:  []string{"AAABBB", "XXXYYY"},
+		Bundle: true,
+	})
+	gof.On("Prices", testAB, testXY).Return(map[gofer.Pair]*gofer.Price{
+		testAB: {Pair: testAB, Price: 1, Time: time.Now()},
+		testXY: {Pair: testXY, Price: 2, Time: time.Now()},
+	}, nil).Once()
+	require.NoError(t, g.Start())
+
+	// Both prices should be sent in a single bundle:
+	g.broadcastCycle([]gofer.Pair{testAB, testXY})
+	msg := <-tra.Messages(messages.PriceBundleMessageName)
+	require.NoError(t, msg.Error)
+	bundle := msg.Message.(*messages.PriceBundle)
+	require.Len(t, bundle.Prices, 2)
+	assert.Equal(t, "AAABBB", bundle.Prices[0].Wat)
+	assert.Equal(t, "XXXYYY", bundle.Prices[1].Wat)
+	assert.NotNil(t, g.last["AAABBB"])
+	assert.NotNil(t, g.last["XXXYYY"])
+}
+
+func TestGhost_duePairs(t *testing.T) {
+	now := time.Now()
+	ss := []*schedule{
+		{pair: testXY, interval: time.Second, next: now},
+		{pair: testAB, interval: time.Minute, next: now.Add(-time.Second)},
+		{pair: gofer.Pair{Base: "C", Quote: "D"}, interval: time.Minute, next: now.Add(time.Second)},
+	}
+
+	assert.Equal(t, []gofer.Pair{testAB, testXY}, duePairs(ss, now))
+	assert.Equal(t, now.Add(time.Second), ss[0].next)
+	assert.Equal(t, now.Add(59*time.Second), ss[1].next)
+	assert.Equal(t, now.Add(time.Second), nextSchedule(ss))
+	assert.Equal(t, time.Second, shortestInterval(ss))
+}
+
+func TestGhost_FetchTimeout(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	g, gof, _ := newTestGhost(ctx, t, Config{
+		Pairs:        []string{"AAABBB", "XXXYYY"},
+		FetchTimeout: 10 * time.Millisecond,
+	})
+
+	// A slow price source must not block the broadcast cycle:
+	unblock := make(chan time.Time)
+	defer close(unblock)
+	gof.On("Prices", testAB, testXY).Return(map[gofer.Pair]*gofer.Price{}, nil).WaitUntil(unblock)
+
+	_, err := g.fetchPrices([]gofer.Pair{testAB, testXY})
+	assert.True(t, errors.As(err, &ErrFetchTimeout{}))
+}
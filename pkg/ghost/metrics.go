@@ -0,0 +1,39 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	broadcastsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ghost",
+		Name:      "broadcasts_total",
+		Help:      "Number of prices broadcast to the network.",
+	}, []string{"pair"})
+	broadcastErrorsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ghost",
+		Name:      "broadcast_errors_total",
+		Help:      "Number of prices which could not be broadcast to the network.",
+	}, []string{"pair"})
+	pricesWithheldMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ghost",
+		Name:      "prices_withheld_total",
+		Help:      "Number of prices withheld because they deviate too much from a reference price.",
+	}, []string{"pair", "reference"})
+)
@@ -0,0 +1,94 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"math"
+	"time"
+)
+
+// Policy describes when a price for a pair should be broadcast. A price is
+// broadcast when it deviates from the last broadcast price by at least
+// Deviation percent or when the Heartbeat has elapsed since the last
+// broadcast. The zero value broadcasts a price on every interval.
+type Policy struct {
+	// Interval describes how often the price is checked. If zero, the
+	// Ghost's interval is used.
+	Interval time.Duration
+	// MaxSourceAge is the maximum age of the oldest source used to
+	// calculate the price. Older prices are never broadcast. If zero,
+	// the age is not checked.
+	MaxSourceAge time.Duration
+	// Heartbeat is the maximum time between two broadcasts. If zero,
+	// a price is broadcast only when it deviates by Deviation percent, or
+	// on every interval if Deviation is zero too.
+	Heartbeat time.Duration
+	// Deviation is the price change in percent, relative to the last
+	// broadcast price, which causes an immediate broadcast. If zero,
+	// the price is broadcast only on heartbeats.
+	Deviation float64
+	// MaxOwnDeviation is the maximum price change in percent, relative to
+	// the last broadcast price. Prices which deviate more are withheld,
+	// unless they are confirmed by peers. If zero, the check is disabled.
+	MaxOwnDeviation float64
+	// OwnDeviationConfirmations is the number of consecutive prices which
+	// deviate from the last broadcast price by more than MaxOwnDeviation,
+	// but not from each other, after which the price is considered
+	// a genuine market move and is broadcast. Otherwise, without peers'
+	// prices, a large move would withhold prices forever. If zero,
+	// DefaultOwnDeviationConfirmations is used.
+	OwnDeviationConfirmations int
+	// MaxPeerDeviation is the maximum difference in percent between a price
+	// and the median of prices sent by other feeders. Prices which deviate
+	// more are withheld. If zero, the check is disabled.
+	MaxPeerDeviation float64
+	// PeerPriceExpiration is the maximum age of prices sent by other
+	// feeders which are used to calculate the median. If zero, all
+	// received prices are used.
+	PeerPriceExpiration time.Duration
+}
+
+// broadcastState holds the last broadcast price for a pair.
+type broadcastState struct {
+	price float64   // price is the last broadcast price.
+	age   time.Time // age is the age of the last broadcast price.
+	time  time.Time // time is the time of the last broadcast.
+}
+
+// shouldBroadcast returns true if the price should be broadcast according
+// to the policy. The last argument is nil if the price has never been
+// broadcast.
+func (p Policy) shouldBroadcast(last *broadcastState, price float64, now time.Time) bool {
+	if last == nil || (p.Heartbeat == 0 && p.Deviation == 0) {
+		return true
+	}
+	if p.Heartbeat > 0 && now.Sub(last.time) >= p.Heartbeat {
+		return true
+	}
+	if p.Deviation > 0 && deviation(last.price, price) >= p.Deviation {
+		return true
+	}
+	return false
+}
+
+// deviation returns the difference between prices in percent, relative to
+// the old price.
+func deviation(old, new float64) float64 {
+	if old == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(new-old) / old * 100
+}
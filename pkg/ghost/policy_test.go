@@ -0,0 +1,51 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_shouldBroadcast(t *testing.T) {
+	now := time.Now()
+	last := &broadcastState{price: 100, time: now.Add(-time.Minute)}
+	tests := []struct {
+		name   string
+		policy Policy
+		last   *broadcastState
+		price  float64
+		want   bool
+	}{
+		{name: "zero-policy", policy: Policy{}, last: last, price: 100, want: true},
+		{name: "first-broadcast", policy: Policy{Heartbeat: time.Hour, Deviation: 1}, last: nil, price: 100, want: true},
+		{name: "no-change", policy: Policy{Heartbeat: time.Hour, Deviation: 1}, last: last, price: 100, want: false},
+		{name: "small-change", policy: Policy{Heartbeat: time.Hour, Deviation: 1}, last: last, price: 100.9, want: false},
+		{name: "deviation-up", policy: Policy{Heartbeat: time.Hour, Deviation: 1}, last: last, price: 101, want: true},
+		{name: "deviation-down", policy: Policy{Heartbeat: time.Hour, Deviation: 1}, last: last, price: 99, want: true},
+		{name: "heartbeat-only", policy: Policy{Heartbeat: time.Hour}, last: last, price: 200, want: false},
+		{name: "deviation-only", policy: Policy{Deviation: 1}, last: last, price: 100.9, want: false},
+		{name: "deviation-only-change", policy: Policy{Deviation: 1}, last: last, price: 101, want: true},
+		{name: "heartbeat", policy: Policy{Heartbeat: time.Minute, Deviation: 1}, last: last, price: 100, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.shouldBroadcast(tt.last, tt.price, now))
+		})
+	}
+}
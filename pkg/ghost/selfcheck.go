@@ -0,0 +1,160 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/log"
+)
+
+const (
+	// LastBroadcastReference is used in the ErrPriceDeviation error when
+	// a price deviates from the last broadcast price.
+	LastBroadcastReference = "last_broadcast"
+	// PeersMedianReference is used in the ErrPriceDeviation error when
+	// a price deviates from the median of prices sent by other feeders.
+	PeersMedianReference = "peers_median"
+
+	// DefaultOwnDeviationConfirmations is the default number of consistent
+	// prices required to accept a price which deviates from the last
+	// broadcast price.
+	DefaultOwnDeviationConfirmations = 3
+)
+
+type ErrPriceDeviation struct {
+	Pair         string
+	Reference    string
+	Deviation    float64
+	MaxDeviation float64
+}
+
+func (e ErrPriceDeviation) Error() string {
+	return fmt.Sprintf(
+		"the price for the %s deviates from the %s by %.2f%%, the maximum deviation is %.2f%%",
+		e.Pair,
+		e.Reference,
+		e.Deviation,
+		e.MaxDeviation,
+	)
+}
+
+// selfCheck verifies if the price does not deviate too much from the last
+// broadcast price or from the median of prices sent by other feeders.
+//
+// If the price is confirmed by peers, a large change since the last
+// broadcast is considered a genuine market move, so the price is not
+// compared with the last broadcast price. Without peers, the move is
+// accepted after several consistent prices. Otherwise, a stale last price
+// would withhold all prices forever after a sudden market move.
+func (g *Ghost) selfCheck(pair string, last *broadcastState, price float64, now time.Time) error {
+	policy := g.policies[pair]
+	if policy.MaxPeerDeviation > 0 {
+		if median, ok := g.peersMedian(pair, policy.PeerPriceExpiration, now); ok {
+			if d := deviation(median, price); d > policy.MaxPeerDeviation {
+				return ErrPriceDeviation{
+					Pair:         pair,
+					Reference:    PeersMedianReference,
+					Deviation:    d,
+					MaxDeviation: policy.MaxPeerDeviation,
+				}
+			}
+			return nil
+		}
+	}
+	if policy.MaxOwnDeviation > 0 && last != nil {
+		if d := deviation(last.price, price); d > policy.MaxOwnDeviation {
+			if g.confirmOwnDeviation(pair, price, policy) {
+				g.log.
+					WithFields(log.Fields{"assetPair": pair, "deviation": d}).
+					Warn("Price accepted after consistent readings")
+				return nil
+			}
+			return ErrPriceDeviation{
+				Pair:         pair,
+				Reference:    LastBroadcastReference,
+				Deviation:    d,
+				MaxDeviation: policy.MaxOwnDeviation,
+			}
+		}
+		g.mu.Lock()
+		delete(g.withheld, pair)
+		g.mu.Unlock()
+	}
+	return nil
+}
+
+// confirmOwnDeviation counts consecutive prices which deviate from the last
+// broadcast price. Prices which do not deviate from the previous withheld
+// price by more than MaxOwnDeviation are considered consistent. It returns
+// true once enough consistent prices are counted.
+func (g *Ghost) confirmOwnDeviation(pair string, price float64, policy Policy) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	confirmations := policy.OwnDeviationConfirmations
+	if confirmations <= 0 {
+		confirmations = DefaultOwnDeviationConfirmations
+	}
+	w, ok := g.withheld[pair]
+	if !ok || deviation(w.price, price) > policy.MaxOwnDeviation {
+		w = &withheldState{}
+		g.withheld[pair] = w
+	}
+	w.price = price
+	w.count++
+	if w.count >= confirmations {
+		delete(g.withheld, pair)
+		return true
+	}
+	return false
+}
+
+// withheldState holds prices withheld because of the deviation from the last
+// broadcast price.
+type withheldState struct {
+	price float64 // price is the last withheld price.
+	count int     // count is the number of consecutive consistent prices.
+}
+
+// peersMedian returns the median of the latest prices sent by other feeders.
+// Prices older than the expiration are ignored. It returns false if there
+// are no prices from other feeders.
+func (g *Ghost) peersMedian(pair string, expiration time.Duration, now time.Time) (float64, bool) {
+	if g.datastore == nil {
+		return 0, false
+	}
+	var prices []float64
+	for fp, msg := range g.datastore.Prices().All() {
+		if fp.AssetPair != pair || fp.Feeder == g.signer.Address() {
+			continue
+		}
+		if expiration > 0 && now.Sub(msg.Price.Age) > expiration {
+			continue
+		}
+		prices = append(prices, msg.Price.Float64Price())
+	}
+	if len(prices) == 0 {
+		return 0, false
+	}
+	sort.Float64s(prices)
+	if n := len(prices); n%2 == 0 {
+		return (prices[n/2-1] + prices[n/2]) / 2, true
+	}
+	return prices[len(prices)/2], true
+}
@@ -0,0 +1,158 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/makerdao/oracle-suite/pkg/datastore"
+	"github.com/makerdao/oracle-suite/pkg/datastore/memory"
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	ethereumMocks "github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+	"github.com/makerdao/oracle-suite/pkg/oracle"
+	"github.com/makerdao/oracle-suite/pkg/transport/messages"
+)
+
+type testDatastore struct {
+	prices *memory.PriceStore
+}
+
+func (d *testDatastore) Start() error                 { return nil }
+func (d *testDatastore) Wait()                        {}
+func (d *testDatastore) Prices() datastore.PriceStore { return d.prices }
+
+func (d *testDatastore) add(from ethereum.Address, price float64, age time.Time) {
+	p := &oracle.Price{Wat: "AAABBB", Age: age}
+	p.SetFloat64Price(price)
+	d.prices.Add(from, &messages.Price{Price: p})
+}
+
+func TestGhost_selfCheck(t *testing.T) {
+	now := time.Now()
+	own := ethereum.HexToAddress("0x01")
+	peer1 := ethereum.HexToAddress("0x02")
+	peer2 := ethereum.HexToAddress("0x03")
+	peer3 := ethereum.HexToAddress("0x04")
+	peer4 := ethereum.HexToAddress("0x05")
+
+	sig := &ethereumMocks.Signer{}
+	sig.On("Address").Return(own)
+
+	ds := &testDatastore{prices: memory.NewPriceStore()}
+	ds.add(own, 1000, now)
+	ds.add(peer1, 99, now)
+	ds.add(peer2, 100, now)
+	ds.add(peer3, 10, now.Add(-time.Hour))
+	ds.add(peer4, 12, now.Add(-time.Hour))
+
+	g := &Ghost{
+		signer:    sig,
+		datastore: ds,
+		policies: map[string]Policy{
+			"AAABBB": {MaxOwnDeviation: 10, MaxPeerDeviation: 5, PeerPriceExpiration: time.Minute},
+		},
+		withheld: make(map[string]*withheldState),
+	}
+	last := &broadcastState{price: 50, time: now}
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		last      *broadcastState
+		price     float64
+		reference string
+	}{
+		{
+			name:   "confirmed-by-peers",
+			policy: Policy{MaxOwnDeviation: 10, MaxPeerDeviation: 5, PeerPriceExpiration: time.Minute},
+			last:   last,
+			price:  100,
+		},
+		{
+			name:      "deviates-from-peers",
+			policy:    Policy{MaxOwnDeviation: 10, MaxPeerDeviation: 5, PeerPriceExpiration: time.Minute},
+			last:      last,
+			price:     110,
+			reference: PeersMedianReference,
+		},
+		{
+			name:      "deviates-from-last-broadcast",
+			policy:    Policy{MaxOwnDeviation: 10},
+			last:      last,
+			price:     100,
+			reference: LastBroadcastReference,
+		},
+		{
+			name:   "first-broadcast",
+			policy: Policy{MaxOwnDeviation: 10},
+			price:  100,
+		},
+		{
+			// The median includes expired prices, so the price is
+			// withheld:
+			name:      "without-expiration",
+			policy:    Policy{MaxPeerDeviation: 5},
+			price:     99,
+			reference: PeersMedianReference,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.policies["AAABBB"] = tt.policy
+			err := g.selfCheck("AAABBB", tt.last, tt.price, now)
+			if tt.reference == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var errDeviation ErrPriceDeviation
+			assert.True(t, errors.As(err, &errDeviation))
+			assert.Equal(t, tt.reference, errDeviation.Reference)
+		})
+	}
+}
+
+func TestGhost_selfCheck_OwnDeviationConfirmations(t *testing.T) {
+	now := time.Now()
+	g := &Ghost{
+		policies: map[string]Policy{
+			"AAABBB": {MaxOwnDeviation: 10, OwnDeviationConfirmations: 3},
+		},
+		withheld: make(map[string]*withheldState),
+		log:      null.New(),
+	}
+	last := &broadcastState{price: 50, time: now}
+
+	// Consistent prices are accepted after the third reading:
+	assert.Error(t, g.selfCheck("AAABBB", last, 100, now))
+	assert.Error(t, g.selfCheck("AAABBB", last, 101, now))
+	assert.NoError(t, g.selfCheck("AAABBB", last, 100, now))
+
+	// Inconsistent prices restart counting:
+	assert.Error(t, g.selfCheck("AAABBB", last, 100, now))
+	assert.Error(t, g.selfCheck("AAABBB", last, 200, now))
+	assert.Error(t, g.selfCheck("AAABBB", last, 200, now))
+	assert.NoError(t, g.selfCheck("AAABBB", last, 200, now))
+
+	// A price within the limit resets the counter:
+	assert.Error(t, g.selfCheck("AAABBB", last, 100, now))
+	assert.NoError(t, g.selfCheck("AAABBB", last, 51, now))
+	assert.Error(t, g.selfCheck("AAABBB", last, 100, now))
+}
@@ -0,0 +1,142 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/oracle"
+)
+
+// StateEntry is the last signed and the last broadcast price for a pair.
+type StateEntry struct {
+	// Price is the last signed price. It may not have been broadcast.
+	Price *oracle.Price `json:"price"`
+	// Time is the time when the price was signed.
+	Time time.Time `json:"time"`
+	// Broadcast is the last broadcast price. It is nil if no price has
+	// been broadcast yet.
+	Broadcast *oracle.Price `json:"broadcast,omitempty"`
+	// BroadcastTime is the time when the Broadcast price was sent.
+	BroadcastTime time.Time `json:"broadcastTime"`
+}
+
+// StateStore persists the last signed and the last broadcast price for every
+// pair, so the Ghost can apply broadcasting policies after a restart.
+type StateStore interface {
+	// Load returns the state entries indexed by the pair name.
+	Load() (map[string]StateEntry, error)
+	// SaveSigned stores the last signed price for the price's pair.
+	SaveSigned(price *oracle.Price, t time.Time) error
+	// SaveBroadcast stores the last broadcast price for the price's pair.
+	SaveBroadcast(price *oracle.Price, t time.Time) error
+}
+
+// FileStateStore is a StateStore which keeps the state in a JSON file.
+type FileStateStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStateStore returns a new FileStateStore instance. The file is
+// created on the first Save call.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load implements the StateStore interface. It returns an empty state if
+// the file does not exist.
+func (s *FileStateStore) Load() (map[string]StateEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// SaveSigned implements the StateStore interface.
+func (s *FileStateStore) SaveSigned(price *oracle.Price, t time.Time) error {
+	return s.update(price.Wat, func(e *StateEntry) {
+		e.Price = price
+		e.Time = t
+	})
+}
+
+// SaveBroadcast implements the StateStore interface.
+func (s *FileStateStore) SaveBroadcast(price *oracle.Price, t time.Time) error {
+	return s.update(price.Wat, func(e *StateEntry) {
+		e.Broadcast = price
+		e.BroadcastTime = t
+	})
+}
+
+// update modifies the entry for the pair and saves the state.
+func (s *FileStateStore) update(pair string, fn func(e *StateEntry)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+	entry := state[pair]
+	fn(&entry)
+	state[pair] = entry
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	// The file is replaced atomically, so the state is never left
+	// partially written:
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+func (s *FileStateStore) load() (map[string]StateEntry, error) {
+	state := make(map[string]StateEntry)
+	b, err := ioutil.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
@@ -0,0 +1,114 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ghost
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/oracle"
+	"github.com/makerdao/oracle-suite/pkg/transport/messages"
+)
+
+func TestFileStateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ghost")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := NewFileStateStore(filepath.Join(dir, "state.json"))
+
+	// Missing file:
+	state, err := s.Load()
+	require.NoError(t, err)
+	assert.Empty(t, state)
+
+	p1 := &oracle.Price{Wat: "AAABBB", Age: time.Unix(100, 0)}
+	p1.SetFloat64Price(1)
+	p2 := &oracle.Price{Wat: "XXXYYY", Age: time.Unix(200, 0)}
+	p2.SetFloat64Price(2)
+	require.NoError(t, s.SaveSigned(p1, time.Unix(101, 0)))
+	require.NoError(t, s.SaveSigned(p2, time.Unix(201, 0)))
+	require.NoError(t, s.SaveBroadcast(p1, time.Unix(102, 0)))
+
+	state, err = NewFileStateStore(filepath.Join(dir, "state.json")).Load()
+	require.NoError(t, err)
+	require.Len(t, state, 2)
+	assert.Equal(t, p1.Val, state["AAABBB"].Price.Val)
+	assert.Equal(t, p1.Age.Unix(), state["AAABBB"].Price.Age.Unix())
+	assert.Equal(t, time.Unix(201, 0).Unix(), state["XXXYYY"].Time.Unix())
+
+	// The last signed and the last broadcast prices are stored separately:
+	assert.Equal(t, p1.Val, state["AAABBB"].Broadcast.Val)
+	assert.Equal(t, time.Unix(101, 0).Unix(), state["AAABBB"].Time.Unix())
+	assert.Equal(t, time.Unix(102, 0).Unix(), state["AAABBB"].BroadcastTime.Unix())
+	assert.Nil(t, state["XXXYYY"].Broadcast)
+}
+
+func TestGhost_State(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	dir, err := ioutil.TempDir("", "ghost")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	broadcast := &oracle.Price{Wat: "AAABBB", Age: now.Add(-time.Minute)}
+	broadcast.SetFloat64Price(1)
+	signed := &oracle.Price{Wat: "AAABBB", Age: now}
+	signed.SetFloat64Price(1.5)
+	state := NewFileStateStore(filepath.Join(dir, "state.json"))
+	require.NoError(t, state.SaveBroadcast(broadcast, now.Add(-time.Minute)))
+	require.NoError(t, state.SaveSigned(signed, now))
+
+	g, gof, tra := newTestGhost(ctx, t, Config{
+		Pairs: []string{"AAABBB"},
+		State: state,
+	})
+	require.NoError(t, g.Start())
+
+	// Prices older than the restored one must not be signed:
+	_, err = g.createPrice(testAB, &gofer.Price{Pair: testAB, Price: 1, Time: now.Add(-time.Minute)})
+	assert.True(t, errors.As(err, &ErrPriceOlderThanLast{}))
+
+	// The last broadcast price is restored for broadcasting policies:
+	require.NotNil(t, g.last["AAABBB"])
+	assert.Equal(t, 1.0, g.last["AAABBB"].price)
+	assert.Equal(t, now.Add(-time.Minute).Unix(), g.last["AAABBB"].time.Unix())
+
+	// New prices are saved to the state once they are signed and broadcast:
+	gof.On("Prices", testAB).Return(map[gofer.Pair]*gofer.Price{
+		testAB: {Pair: testAB, Price: 2, Time: now.Add(time.Minute)},
+	}, nil).Once()
+	g.broadcastCycle([]gofer.Pair{testAB})
+	msg := <-tra.Messages(messages.PriceMessageName)
+	require.NoError(t, msg.Error)
+
+	s, err := state.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, s["AAABBB"].Price.Float64Price())
+	assert.Equal(t, now.Add(time.Minute).Unix(), s["AAABBB"].Price.Age.Unix())
+	assert.Equal(t, 2.0, s["AAABBB"].Broadcast.Float64Price())
+}
@@ -16,6 +16,7 @@
 package gofer
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -95,6 +96,14 @@ type Price struct {
 	Error      string
 }
 
+// PriceSeries is a list of prices for a single pair fetched from a single
+// origin, ordered from the oldest to the newest.
+type PriceSeries struct {
+	Origin string
+	Pair   Pair
+	Prices []*Price
+}
+
 // Gofer provides prices for asset pairs.
 type Gofer interface {
 	// Models describes price models which are used to calculate prices.
@@ -116,3 +125,23 @@ type StartableGofer interface {
 	Start() error
 	Wait()
 }
+
+// SubscribableGofer interface represents a Gofer instances that can notify
+// about price updates.
+type SubscribableGofer interface {
+	Gofer
+	// Subscribe returns a channel to which prices for given pairs are sent
+	// every time they are updated. If no pairs are specified, updates for all
+	// pairs are sent. The channel is closed when the context is cancelled.
+	Subscribe(ctx context.Context, pairs ...Pair) (<-chan *Price, error)
+}
+
+// HistoricalGofer interface represents a Gofer instances that keep a history
+// of prices fetched from origins.
+type HistoricalGofer interface {
+	Gofer
+	// History returns prices fetched since the given time from origins used
+	// to calculate prices for given pairs. If no pairs are specified, history
+	// for all pairs is returned.
+	History(since time.Time, pairs ...Pair) (map[Pair][]*PriceSeries, error)
+}
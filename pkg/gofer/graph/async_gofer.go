@@ -18,32 +18,51 @@ package graph
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 	"github.com/makerdao/oracle-suite/pkg/gofer/graph/feeder"
 	"github.com/makerdao/oracle-suite/pkg/gofer/graph/nodes"
 )
 
-// AsyncGofer implements the gofer.Gofer interface. It works just like Graph
-// but allows to update prices asynchronously.
+// subscriptionBufferSize is a size of a subscription channel buffer. If
+// a subscriber does not read prices fast enough, new prices are dropped.
+const subscriptionBufferSize = 64
+
+// AsyncGofer implements the gofer.Gofer and gofer.SubscribableGofer
+// interfaces. It works just like Graph but allows to update prices
+// asynchronously.
 type AsyncGofer struct {
 	*Gofer
 	ctx    context.Context
+	mu     sync.RWMutex
 	feeder *feeder.Feeder
+	subs   map[*subscription]struct{}
 	doneCh chan struct{}
 }
 
+type subscription struct {
+	pairs map[gofer.Pair]struct{}
+	ch    chan *gofer.Price
+}
+
 // NewAsyncGofer returns a new AsyncGofer instance.
 func NewAsyncGofer(ctx context.Context, g map[gofer.Pair]nodes.Aggregator, f *feeder.Feeder) (*AsyncGofer, error) {
 	if ctx == nil {
 		return nil, errors.New("context must not be nil")
 	}
-	return &AsyncGofer{
+	if f == nil {
+		return nil, errors.New("feeder must not be nil")
+	}
+	a := &AsyncGofer{
 		Gofer:  NewGofer(g, nil),
 		ctx:    ctx,
 		feeder: f,
+		subs:   make(map[*subscription]struct{}),
 		doneCh: make(chan struct{}),
-	}, nil
+	}
+	f.OnUpdate(a.updateHandler)
+	return a, nil
 }
 
 // Start starts asynchronous price updater.
@@ -58,6 +77,74 @@ func (a *AsyncGofer) Wait() {
 	<-a.doneCh
 }
 
+// Subscribe implements the gofer.SubscribableGofer interface. Prices sent to
+// the channel are shared between subscribers and must not be modified.
+func (a *AsyncGofer) Subscribe(ctx context.Context, pairs ...gofer.Pair) (<-chan *gofer.Price, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	sub := &subscription{
+		pairs: make(map[gofer.Pair]struct{}),
+		ch:    make(chan *gofer.Price, subscriptionBufferSize),
+	}
+	for _, p := range pairs {
+		if _, ok := a.graphs[p]; !ok {
+			return nil, ErrPairNotFound{Pair: p}
+		}
+		sub.pairs[p] = struct{}{}
+	}
+
+	a.mu.Lock()
+	a.subs[sub] = struct{}{}
+	a.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		a.mu.Lock()
+		delete(a.subs, sub)
+		close(sub.ch)
+		a.mu.Unlock()
+	}()
+
+	return sub.ch, nil
+}
+
+// updateHandler sends prices of all root nodes that depend on updated nodes
+// to subscribers.
+func (a *AsyncGofer) updateHandler(updated []feeder.Feedable) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.subs) == 0 {
+		return
+	}
+
+	updatedMap := map[interface{}]struct{}{}
+	for _, n := range updated {
+		updatedMap[n] = struct{}{}
+	}
+	for pair, root := range a.graphs {
+		affected := false
+		nodes.Walk(func(n nodes.Node) {
+			if _, ok := updatedMap[n]; ok {
+				affected = true
+			}
+		}, root)
+		if !affected {
+			continue
+		}
+		price := mapGraphPrice(root.Price())
+		for sub := range a.subs {
+			if _, ok := sub.pairs[pair]; !ok && len(sub.pairs) > 0 {
+				continue
+			}
+			select {
+			case sub.ch <- price:
+			default:
+			}
+		}
+	}
+}
+
 func (a *AsyncGofer) contextCancelHandler() {
 	defer func() { close(a.doneCh) }()
 	<-a.ctx.Done()
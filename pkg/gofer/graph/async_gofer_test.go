@@ -0,0 +1,102 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/gofer/graph/feeder"
+	"github.com/makerdao/oracle-suite/pkg/gofer/graph/nodes"
+	"github.com/makerdao/oracle-suite/pkg/gofer/origins"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+func newTestAsyncGofer(ctx context.Context, t *testing.T) *AsyncGofer {
+	ab := testPairs["A/B"]
+	xy := testPairs["X/Y"]
+	ttl := time.Minute
+
+	abGraph := nodes.NewMedianAggregatorNode(ab, 0)
+	abGraph.AddChild(nodes.NewOriginNode(nodes.OriginPair{Origin: "a", Pair: ab}, ttl, ttl))
+	xyGraph := nodes.NewMedianAggregatorNode(xy, 0)
+	xyGraph.AddChild(nodes.NewOriginNode(nodes.OriginPair{Origin: "x", Pair: xy}, ttl, ttl))
+
+	f := feeder.NewFeeder(ctx, origins.NewSet(map[string]origins.Handler{
+		"a": &testExchange{},
+		"x": &testExchange{},
+	}, 10), null.New())
+
+	g, err := NewAsyncGofer(ctx, map[gofer.Pair]nodes.Aggregator{ab: abGraph, xy: xyGraph}, f)
+	require.NoError(t, err)
+	return g
+}
+
+func TestAsyncGofer_Subscribe(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	g := newTestAsyncGofer(ctx, t)
+	subCtx, subCtxCancel := context.WithCancel(ctx)
+	ch, err := g.Subscribe(subCtx, testPairs["X/Y"])
+	require.NoError(t, err)
+	require.NoError(t, g.Start())
+
+	select {
+	case p := <-ch:
+		assert.Equal(t, testPairs["X/Y"], p.Pair)
+		assert.Equal(t, 10.0, p.Price)
+	case <-time.After(time.Second):
+		assert.Fail(t, "price was not received")
+	}
+
+	// Only the subscribed pair should be sent:
+	select {
+	case p := <-ch:
+		assert.Fail(t, "unexpected price", p.Pair.String())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The channel should be closed after cancelling the context:
+	subCtxCancel()
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		assert.Fail(t, "channel was not closed")
+	}
+}
+
+func TestAsyncGofer_Subscribe_MissingPair(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	g := newTestAsyncGofer(ctx, t)
+	_, err := g.Subscribe(ctx, gofer.Pair{})
+
+	assert.True(t, errors.As(err, &ErrPairNotFound{}))
+}
+
+func TestNewAsyncGofer_NilFeeder(t *testing.T) {
+	_, err := NewAsyncGofer(context.Background(), map[gofer.Pair]nodes.Aggregator{}, nil)
+	assert.Error(t, err)
+}
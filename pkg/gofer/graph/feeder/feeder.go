@@ -17,6 +17,7 @@ package feeder
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -65,13 +66,19 @@ type Feedable interface {
 	Price() nodes.OriginPrice
 }
 
+// UpdateHandler is a function which is called after nodes are updated by
+// the Feeder. The list contains only nodes that ingested a new price.
+type UpdateHandler func(updated []Feedable)
+
 // Feeder sets prices from origins to the Feedable nodes.
 type Feeder struct {
 	ctx context.Context
+	mu  sync.RWMutex
 
-	set    *origins.Set
-	log    log.Logger
-	doneCh chan struct{}
+	set      *origins.Set
+	log      log.Logger
+	handlers []UpdateHandler
+	doneCh   chan struct{}
 }
 
 // NewFeeder creates new Feeder instance.
@@ -90,6 +97,13 @@ func (f *Feeder) Feed(ns ...nodes.Node) Warnings {
 	return f.fetchPricesAndFeedThemToFeedableNodes(f.findFeedableNodes(ns, time.Now()))
 }
 
+// OnUpdate adds a handler which is called every time nodes are updated.
+func (f *Feeder) OnUpdate(h UpdateHandler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers = append(f.handlers, h)
+}
+
 // Start starts a goroutine which updates prices as often as the lowest TTL is.
 func (f *Feeder) Start(ns ...nodes.Node) error {
 	f.log.Infof("Starting")
@@ -158,6 +172,7 @@ func (f *Feeder) findFeedableNodes(ns []nodes.Node, t time.Time) []Feedable {
 
 func (f *Feeder) fetchPricesAndFeedThemToFeedableNodes(ns []Feedable) Warnings {
 	var warns Warnings
+	var updated []Feedable
 
 	// originPair is used as a key in a map to easily find
 	// Feedable nodes for given origin and pair
@@ -205,14 +220,28 @@ func (f *Feeder) fetchPricesAndFeedThemToFeedableNodes(ns []Feedable) Warnings {
 					warns.List = append(warns.List, price.Error)
 				} else if iErr := feedable.Ingest(price); iErr != nil {
 					warns.List = append(warns.List, iErr)
+				} else {
+					updated = append(updated, feedable)
 				}
 			}
 		}
 	}
 
+	if len(updated) > 0 {
+		f.notify(updated)
+	}
+
 	return warns
 }
 
+func (f *Feeder) notify(updated []Feedable) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, h := range f.handlers {
+		h(updated)
+	}
+}
+
 func appendPairIfUnique(pairs []origins.Pair, pair origins.Pair) []origins.Pair {
 	exists := false
 	for _, p := range pairs {
@@ -426,3 +426,45 @@ func TestFeeder_ch11427(t *testing.T) {
 	time.Sleep(2500 * time.Millisecond)
 	assert.False(t, o.Expired())
 }
+
+func TestFeeder_OnUpdate(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	s := originsSetMock(map[string][]origins.Price{
+		"test": {
+			origins.Price{
+				Pair:      origins.Pair{Base: "A", Quote: "B"},
+				Price:     10,
+				Timestamp: time.Now(),
+			},
+		},
+	}, 0, false)
+
+	f := NewFeeder(ctx, s, null.New())
+
+	o1 := nodes.NewOriginNode(nodes.OriginPair{
+		Origin: "test",
+		Pair:   gofer.Pair{Base: "A", Quote: "B"},
+	}, time.Minute, time.Minute)
+	o2 := nodes.NewOriginNode(nodes.OriginPair{
+		Origin: "unknown",
+		Pair:   gofer.Pair{Base: "A", Quote: "B"},
+	}, time.Minute, time.Minute)
+
+	// The o2 node has a valid price, so an error from the unknown origin
+	// will not override it:
+	assert.NoError(t, o2.Ingest(nodes.OriginPrice{
+		PairPrice: nodes.PairPrice{Pair: gofer.Pair{Base: "A", Quote: "B"}, Time: time.Now()},
+		Origin:    "unknown",
+	}))
+
+	var updated []Feedable
+	f.OnUpdate(func(u []Feedable) {
+		updated = append(updated, u...)
+	})
+	f.Feed(o1, o2)
+
+	// Only the node which ingested a price should be reported:
+	assert.Equal(t, []Feedable{o1}, updated)
+}
@@ -18,7 +18,9 @@ package graph
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 	"github.com/makerdao/oracle-suite/pkg/gofer/graph/feeder"
@@ -92,6 +94,22 @@ func (g *Gofer) Prices(pairs ...gofer.Pair) (map[gofer.Pair]*gofer.Price, error)
 	return res, nil
 }
 
+// History implements the gofer.HistoricalGofer interface. Prices are
+// returned only for origin nodes with enabled history.
+func (g *Gofer) History(since time.Time, pairs ...gofer.Pair) (map[gofer.Pair][]*gofer.PriceSeries, error) {
+	ns, err := g.findNodes(pairs...)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[gofer.Pair][]*gofer.PriceSeries)
+	for _, n := range ns {
+		if n, ok := n.(nodes.Aggregator); ok {
+			res[n.Pair()] = mapGraphHistory(n, since)
+		}
+	}
+	return res, nil
+}
+
 // Pairs implements the gofer.Gofer interface.
 func (g *Gofer) Pairs() ([]gofer.Pair, error) {
 	var ps []gofer.Pair
@@ -149,6 +167,29 @@ func mapGraphNodes(n nodes.Node) *gofer.Model {
 	return gn
 }
 
+func mapGraphHistory(n nodes.Node, since time.Time) []*gofer.PriceSeries {
+	var ss []*gofer.PriceSeries
+	nodes.Walk(func(n nodes.Node) {
+		if o, ok := n.(*nodes.OriginNode); ok {
+			s := &gofer.PriceSeries{
+				Origin: o.OriginPair().Origin,
+				Pair:   o.OriginPair().Pair,
+			}
+			for _, p := range o.History(since) {
+				s.Prices = append(s.Prices, mapGraphPrice(p))
+			}
+			ss = append(ss, s)
+		}
+	}, n)
+	sort.Slice(ss, func(i, j int) bool {
+		if ss[i].Pair.Equal(ss[j].Pair) {
+			return ss[i].Origin < ss[j].Origin
+		}
+		return ss[i].Pair.String() < ss[j].Pair.String()
+	})
+	return ss
+}
+
 func mapGraphPrice(t interface{}) *gofer.Price {
 	gt := &gofer.Price{
 		Parameters: make(map[string]string),
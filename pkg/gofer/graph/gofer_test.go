@@ -326,3 +326,47 @@ func TestGofer_Prices_MissingPair(t *testing.T) {
 
 	assert.True(t, errors.As(err, &ErrPairNotFound{}))
 }
+
+func TestGofer_History(t *testing.T) {
+	ab := testPairs["A/B"]
+	exp := 3600 * time.Second
+
+	abGraph := nodes.NewMedianAggregatorNode(ab, 0)
+	abc1 := nodes.NewOriginNode(nodes.OriginPair{Origin: "b", Pair: ab}, exp, exp)
+	abc2 := nodes.NewOriginNode(nodes.OriginPair{Origin: "a", Pair: ab}, exp, exp)
+	abc1.SetHistory(nodes.NewPriceHistory(10, 0))
+	abGraph.AddChild(abc1)
+	abGraph.AddChild(abc2)
+
+	for _, ts := range []time.Time{testTime.Add(-2 * time.Minute), testTime} {
+		err := abc1.Ingest(nodes.OriginPrice{
+			PairPrice: nodes.PairPrice{Pair: ab, Price: 10, Time: ts},
+			Origin:    "b",
+		})
+		assert.NoError(t, err)
+	}
+
+	g := NewGofer(map[gofer.Pair]nodes.Aggregator{ab: abGraph}, nil)
+	r, err := g.History(testTime.Add(-time.Minute), ab)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[gofer.Pair][]*gofer.PriceSeries{
+		ab: {
+			{Origin: "a", Pair: ab},
+			{Origin: "b", Pair: ab, Prices: []*gofer.Price{{
+				Type:       "origin",
+				Parameters: map[string]string{"origin": "b"},
+				Pair:       ab,
+				Price:      10,
+				Time:       testTime,
+			}}},
+		},
+	}, r)
+}
+
+func TestGofer_History_MissingPair(t *testing.T) {
+	g := NewGofer(testGraph, testFeeder)
+	_, err := g.History(time.Time{}, gofer.Pair{})
+
+	assert.True(t, errors.As(err, &ErrPairNotFound{}))
+}
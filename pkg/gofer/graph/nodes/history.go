@@ -0,0 +1,84 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"sync"
+	"time"
+)
+
+// PriceHistory is a bounded ring buffer of origin prices. When the buffer is
+// full, the oldest price is overwritten. Prices older than the retention
+// period are never returned.
+type PriceHistory struct {
+	mu sync.RWMutex
+
+	prices    []OriginPrice
+	next      int
+	full      bool
+	retention time.Duration
+}
+
+// NewPriceHistory returns a new PriceHistory which holds up to size prices.
+// If retention is zero, prices are kept until they are overwritten.
+func NewPriceHistory(size int, retention time.Duration) *PriceHistory {
+	if size < 1 {
+		size = 1
+	}
+	return &PriceHistory{
+		prices:    make([]OriginPrice, size),
+		retention: retention,
+	}
+}
+
+// Add adds a price to the history.
+func (h *PriceHistory) Add(price OriginPrice) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.prices[h.next] = price
+	h.next = (h.next + 1) % len(h.prices)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Since returns prices which are not older than the given time, ordered
+// from the oldest to the newest.
+func (h *PriceHistory) Since(since time.Time) []OriginPrice {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.retention > 0 {
+		if min := time.Now().Add(-h.retention); since.Before(min) {
+			since = min
+		}
+	}
+
+	start, count := 0, h.next
+	if h.full {
+		start, count = h.next, len(h.prices)
+	}
+	var prices []OriginPrice
+	for i := 0; i < count; i++ {
+		p := h.prices[(start+i)%len(h.prices)]
+		if p.Time.Before(since) {
+			continue
+		}
+		prices = append(prices, p)
+	}
+	return prices
+}
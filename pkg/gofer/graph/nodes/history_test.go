@@ -0,0 +1,89 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+)
+
+func historyTestPrice(price float64, ts time.Time) OriginPrice {
+	return OriginPrice{
+		PairPrice: PairPrice{
+			Pair:  gofer.Pair{Base: "A", Quote: "B"},
+			Price: price,
+			Time:  ts,
+		},
+		Origin: "foo",
+	}
+}
+
+func historyPrices(ps []OriginPrice) []float64 {
+	var r []float64
+	for _, p := range ps {
+		r = append(r, p.Price)
+	}
+	return r
+}
+
+func TestPriceHistory_Since(t *testing.T) {
+	now := time.Now()
+	h := NewPriceHistory(3, 0)
+
+	assert.Empty(t, h.Since(time.Time{}))
+
+	h.Add(historyTestPrice(1, now.Add(-3*time.Minute)))
+	h.Add(historyTestPrice(2, now.Add(-2*time.Minute)))
+	assert.Equal(t, []float64{1, 2}, historyPrices(h.Since(time.Time{})))
+
+	// The oldest price should be overwritten:
+	h.Add(historyTestPrice(3, now.Add(-1*time.Minute)))
+	h.Add(historyTestPrice(4, now))
+	assert.Equal(t, []float64{2, 3, 4}, historyPrices(h.Since(time.Time{})))
+	assert.Equal(t, []float64{3, 4}, historyPrices(h.Since(now.Add(-90*time.Second))))
+}
+
+func TestPriceHistory_Retention(t *testing.T) {
+	now := time.Now()
+	h := NewPriceHistory(10, 90*time.Second)
+
+	h.Add(historyTestPrice(1, now.Add(-2*time.Minute)))
+	h.Add(historyTestPrice(2, now.Add(-1*time.Minute)))
+	h.Add(historyTestPrice(3, now))
+
+	assert.Equal(t, []float64{2, 3}, historyPrices(h.Since(time.Time{})))
+}
+
+func TestOriginNode_History(t *testing.T) {
+	op := OriginPair{
+		Origin: "foo",
+		Pair:   gofer.Pair{Base: "A", Quote: "B"},
+	}
+	o := NewOriginNode(op, originTestTTL, originTestTTL)
+
+	// History is disabled by default:
+	assert.NoError(t, o.Ingest(historyTestPrice(1, time.Now())))
+	assert.Nil(t, o.History(time.Time{}))
+
+	o.SetHistory(NewPriceHistory(10, 0))
+	assert.NoError(t, o.Ingest(historyTestPrice(2, time.Now())))
+	assert.Error(t, o.Ingest(OriginPrice{Origin: "bar"}))
+	assert.Equal(t, []float64{2}, historyPrices(o.History(time.Time{})))
+}
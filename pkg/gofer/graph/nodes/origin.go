@@ -69,6 +69,7 @@ type OriginNode struct {
 
 	originPair OriginPair
 	price      OriginPrice
+	history    *PriceHistory
 	minTTL     time.Duration
 	maxTTL     time.Duration
 }
@@ -81,6 +82,24 @@ func NewOriginNode(originPair OriginPair, minTTL time.Duration, maxTTL time.Dura
 	}
 }
 
+// SetHistory enables recording of ingested prices in the given history.
+func (n *OriginNode) SetHistory(history *PriceHistory) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.history = history
+}
+
+// History returns prices ingested since the given time. If the history is
+// not enabled, nil is returned.
+func (n *OriginNode) History(since time.Time) []OriginPrice {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.history == nil {
+		return nil
+	}
+	return n.history.Since(since)
+}
+
 // OriginPair implements the Feedable interface.
 func (n *OriginNode) OriginPair() OriginPair {
 	return n.originPair
@@ -108,6 +127,9 @@ func (n *OriginNode) Ingest(price OriginPrice) error {
 
 	if err == nil {
 		n.price = price
+		if n.history != nil {
+			n.history.Add(price)
+		}
 	}
 
 	return err
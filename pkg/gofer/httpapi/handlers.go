@@ -0,0 +1,215 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/makerdao/oracle-suite/internal/gofer/marshal"
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/gofer/graph"
+)
+
+func (s *Server) pairsHandler(rw http.ResponseWriter, r *http.Request) {
+	if !allowGet(rw, r) {
+		return
+	}
+	models, err := s.gofer.Models()
+	if err != nil {
+		writeError(rw, goferErrorCode(err), err)
+		return
+	}
+	var items []interface{}
+	for _, p := range sortPairs(models) {
+		items = append(items, models[p])
+	}
+	writeItems(rw, http.StatusOK, items...)
+}
+
+func (s *Server) pricesHandler(rw http.ResponseWriter, r *http.Request) {
+	if !allowGet(rw, r) {
+		return
+	}
+	pairs, err := queryPairs(r)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+	prices, err := s.gofer.Prices(pairs...)
+	if err != nil {
+		writeError(rw, goferErrorCode(err), err)
+		return
+	}
+	var items []interface{}
+	for _, p := range sortPairs(prices) {
+		items = append(items, prices[p])
+	}
+	writeItems(rw, http.StatusOK, items...)
+}
+
+func (s *Server) modelsHandler(rw http.ResponseWriter, r *http.Request) {
+	if !allowGet(rw, r) {
+		return
+	}
+	pairs, err := queryPairs(r)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+	models, err := s.gofer.Models(pairs...)
+	if err != nil {
+		writeError(rw, goferErrorCode(err), err)
+		return
+	}
+	var items []jsonModel
+	for _, p := range sortPairs(models) {
+		items = append(items, jsonModelFromGoferModel(models[p]))
+	}
+	writeJSON(rw, http.StatusOK, items)
+}
+
+func (s *Server) healthHandler(rw http.ResponseWriter, r *http.Request) {
+	if !allowGet(rw, r) {
+		return
+	}
+	if _, err := s.gofer.Pairs(); err != nil {
+		writeJSON(rw, http.StatusServiceUnavailable, jsonHealth{Status: "error", Error: err.Error()})
+		return
+	}
+	writeJSON(rw, http.StatusOK, jsonHealth{Status: "ok"})
+}
+
+type jsonHealth struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// jsonModel is a JSON representation of the gofer.Model. The marshal.JSON
+// format represents models only as pair names, which is not enough to
+// describe a model over the API.
+type jsonModel struct {
+	Type       string            `json:"type"`
+	Base       string            `json:"base"`
+	Quote      string            `json:"quote"`
+	Parameters map[string]string `json:"params,omitempty"`
+	Models     []jsonModel       `json:"models,omitempty"`
+}
+
+func jsonModelFromGoferModel(m *gofer.Model) jsonModel {
+	var models []jsonModel
+	for _, c := range m.Models {
+		models = append(models, jsonModelFromGoferModel(c))
+	}
+	return jsonModel{
+		Type:       m.Type,
+		Base:       m.Pair.Base,
+		Quote:      m.Pair.Quote,
+		Parameters: m.Parameters,
+		Models:     models,
+	}
+}
+
+// queryPairs returns pairs from the "pair" query parameter. Pairs may be
+// given as a comma separated list or as multiple parameters.
+func queryPairs(r *http.Request) ([]gofer.Pair, error) {
+	var ss []string
+	for _, v := range r.URL.Query()["pair"] {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				ss = append(ss, p)
+			}
+		}
+	}
+	return gofer.NewPairs(ss...)
+}
+
+func sortPairs(m interface{}) []gofer.Pair {
+	var ps []gofer.Pair
+	switch typedMap := m.(type) {
+	case map[gofer.Pair]*gofer.Price:
+		for p := range typedMap {
+			ps = append(ps, p)
+		}
+	case map[gofer.Pair]*gofer.Model:
+		for p := range typedMap {
+			ps = append(ps, p)
+		}
+	}
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].String() < ps[j].String()
+	})
+	return ps
+}
+
+func allowGet(rw http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		rw.Header().Set("Allow", http.MethodGet)
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+// writeItems writes items using the marshal.JSON format.
+func writeItems(rw http.ResponseWriter, code int, items ...interface{}) {
+	if len(items) == 0 {
+		writeJSON(rw, code, []interface{}{})
+		return
+	}
+	b, err := marshal.Marshall(marshal.JSON, items...)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	_, _ = rw.Write(b)
+}
+
+// goferErrorCode returns the HTTP status code for an error returned by
+// Gofer. Unknown pairs are reported as not found.
+func goferErrorCode(err error) int {
+	if errors.As(err, &graph.ErrPairNotFound{}) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func writeError(rw http.ResponseWriter, code int, err error) {
+	b, mErr := marshal.Marshall(marshal.NDJSON, err)
+	if mErr != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	_, _ = rw.Write(b)
+}
+
+func writeJSON(rw http.ResponseWriter, code int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	_, _ = rw.Write(append(b, '\n'))
+}
@@ -0,0 +1,97 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/makerdao/oracle-suite/internal/gofer/marshal"
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+)
+
+// defaultHistorySince is used when the "since" query parameter is omitted.
+const defaultHistorySince = time.Hour
+
+var errHistoryNotSupported = errors.New("price history is not supported")
+
+// historyHandler returns prices fetched from origins used to calculate
+// prices for given pairs. The response is a JSON object where keys are
+// pair names and values are lists of price series, one for each origin.
+func (s *Server) historyHandler(rw http.ResponseWriter, r *http.Request) {
+	if !allowGet(rw, r) {
+		return
+	}
+	hg, ok := s.gofer.(gofer.HistoricalGofer)
+	if !ok {
+		writeError(rw, http.StatusNotImplemented, errHistoryNotSupported)
+		return
+	}
+	pairs, err := queryPairs(r)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+	since, err := querySince(r, time.Now())
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+	history, err := hg.History(since, pairs...)
+	if err != nil {
+		writeError(rw, goferErrorCode(err), err)
+		return
+	}
+	res := make(map[string]json.RawMessage)
+	for pair, series := range history {
+		var items []interface{}
+		for _, s := range series {
+			items = append(items, s)
+		}
+		if len(items) == 0 {
+			res[pair.String()] = json.RawMessage("[]")
+			continue
+		}
+		b, err := marshal.Marshall(marshal.JSON, items...)
+		if err != nil {
+			writeError(rw, http.StatusInternalServerError, err)
+			return
+		}
+		res[pair.String()] = b
+	}
+	writeJSON(rw, http.StatusOK, res)
+}
+
+// querySince returns time from the "since" query parameter. The parameter
+// may be given as a duration relative to now (e.g. "1h") or as a RFC3339
+// timestamp.
+func querySince(r *http.Request, now time.Time) (time.Time, error) {
+	v := r.URL.Query().Get("since")
+	if v == "" {
+		return now.Add(-defaultHistorySince), nil
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since parameter: %s", v)
+	}
+	return t, nil
+}
@@ -0,0 +1,147 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/makerdao/oracle-suite/internal/httpserver"
+	"github.com/makerdao/oracle-suite/internal/httpserver/middleware"
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/log"
+)
+
+const LoggerTag = "GOFER_HTTP"
+
+type Config struct {
+	// Gofer instance which will be used by the server. The server does not
+	// start the Gofer instance, it must be started by the caller if
+	// necessary.
+	Gofer gofer.Gofer
+	// Address is a listen address of the HTTP server.
+	Address string
+	// EnableCORS adds CORS headers to responses, allowing requests from any
+	// origin.
+	EnableCORS bool
+	Logger     log.Logger
+}
+
+// Server serves the Gofer API over HTTP using the JSON format.
+//
+// The following endpoints are available:
+//   GET /pairs                      - list of all asset pairs
+//   GET /prices?pair=BTC/USD[,...]  - prices for given pairs, or all pairs
+//   GET /models?pair=BTC/USD[,...]  - price models for given pairs, or all pairs
+//   GET /stream?pair=BTC/USD[,...]  - stream of price updates using
+//                                     Server-Sent Events
+//   GET /history?pair=BTC/USD[,...]&since=1h
+//                                   - prices fetched from origins since
+//                                     given duration or RFC3339 time
+//   GET /health                     - health status
+type Server struct {
+	ctx    context.Context
+	doneCh chan struct{}
+
+	srv   *httpserver.HTTPServer
+	mux   *http.ServeMux
+	gofer gofer.Gofer
+	log   log.Logger
+}
+
+// New returns a new Server instance.
+func New(ctx context.Context, cfg Config) (*Server, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	s := &Server{
+		ctx:    ctx,
+		doneCh: make(chan struct{}),
+		mux:    http.NewServeMux(),
+		gofer:  cfg.Gofer,
+		log:    cfg.Logger.WithField("tag", LoggerTag),
+	}
+	s.mux.HandleFunc("/pairs", s.pairsHandler)
+	s.mux.HandleFunc("/prices", s.pricesHandler)
+	s.mux.HandleFunc("/models", s.modelsHandler)
+	s.mux.HandleFunc("/stream", s.streamHandler)
+	s.mux.HandleFunc("/history", s.historyHandler)
+	s.mux.HandleFunc("/health", s.healthHandler)
+
+	s.srv = httpserver.New(ctx, &http.Server{
+		Addr:    cfg.Address,
+		Handler: s.mux,
+	})
+	s.srv.Use(&middleware.Recover{
+		Recover: func(err interface{}) {
+			s.log.WithField("panic", fmt.Sprintf("%s", err)).Error("Server handler crashed")
+		},
+	})
+	s.srv.Use(&middleware.Logger{Log: s.log})
+	if cfg.EnableCORS {
+		s.srv.Use(&middleware.CORS{
+			Origin:  func(r *http.Request) string { return "*" },
+			Headers: func(*http.Request) string { return "Content-Type" },
+			Methods: func(*http.Request) string { return "GET" },
+		})
+	}
+	return s, nil
+}
+
+// Handle registers an additional handler for the given pattern. It must be
+// called before Start.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start starts the HTTP server.
+func (s *Server) Start() error {
+	s.log.Infof("Starting")
+	if err := s.srv.ListenAndServe(); err != nil {
+		return err
+	}
+	go s.contextCancelHandler()
+	return nil
+}
+
+// Wait waits until the server's context is cancelled.
+func (s *Server) Wait() {
+	<-s.doneCh
+}
+
+// Addr returns the server's network address. It returns nil if the server
+// is not started.
+func (s *Server) Addr() net.Addr {
+	return s.srv.Addr()
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	s.srv.ServeHTTP(rw, r)
+}
+
+func (s *Server) contextCancelHandler() {
+	defer func() { close(s.doneCh) }()
+	defer s.log.Info("Stopped")
+	<-s.ctx.Done()
+
+	if err := s.srv.Wait(); err != nil {
+		s.log.WithError(err).Error("Unable to close HTTP server")
+	}
+}
@@ -0,0 +1,203 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/gofer/graph"
+	"github.com/makerdao/oracle-suite/pkg/gofer/mocks"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+var (
+	ab = gofer.Pair{Base: "A", Quote: "B"}
+	cd = gofer.Pair{Base: "C", Quote: "D"}
+)
+
+func newTestServer(t *testing.T) (*Server, *mocks.Gofer) {
+	g := &mocks.Gofer{}
+	s, err := New(context.Background(), Config{
+		Gofer:   g,
+		Address: "127.0.0.1:0",
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	return s, g
+}
+
+func serve(s *Server, method, url string) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	s.ServeHTTP(rw, httptest.NewRequest(method, url, nil))
+	return rw
+}
+
+func TestServer_Pairs(t *testing.T) {
+	s, g := newTestServer(t)
+	g.On("Models").Return(map[gofer.Pair]*gofer.Model{
+		cd: {Type: "median", Pair: cd},
+		ab: {Type: "median", Pair: ab},
+	}, nil)
+
+	rw := serve(s, http.MethodGet, "/pairs")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `["A/B","C/D"]`, rw.Body.String())
+}
+
+func TestServer_Prices(t *testing.T) {
+	s, g := newTestServer(t)
+	ts := time.Unix(1, 0)
+	g.On("Prices", ab, cd).Return(map[gofer.Pair]*gofer.Price{
+		ab: {Type: "median", Pair: ab, Price: 1, Time: ts},
+		cd: {Type: "median", Pair: cd, Price: 2, Time: ts, Error: "err"},
+	}, nil)
+
+	rw := serve(s, http.MethodGet, "/prices?pair=A/B,C/D")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `[
+		{"type":"median","base":"A","quote":"B","price":1,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:01Z"},
+		{"type":"median","base":"C","quote":"D","price":2,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:01Z","error":"err"}
+	]`, rw.Body.String())
+}
+
+func TestServer_PricesMultipleParams(t *testing.T) {
+	s, g := newTestServer(t)
+	g.On("Prices", ab, cd).Return(map[gofer.Pair]*gofer.Price{}, nil)
+
+	rw := serve(s, http.MethodGet, "/prices?pair=A/B&pair=C/D")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `[]`, rw.Body.String())
+}
+
+func TestServer_PricesInvalidPair(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	rw := serve(s, http.MethodGet, "/prices?pair=AB")
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"error"`)
+}
+
+func TestServer_PricesError(t *testing.T) {
+	s, g := newTestServer(t)
+	g.On("Prices").Return(map[gofer.Pair]*gofer.Price(nil), errors.New("err"))
+
+	rw := serve(s, http.MethodGet, "/prices")
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	assert.JSONEq(t, `{"error":"err"}`, rw.Body.String())
+}
+
+func TestServer_PricesUnknownPair(t *testing.T) {
+	s, g := newTestServer(t)
+	g.On("Prices", ab).Return(map[gofer.Pair]*gofer.Price(nil), graph.ErrPairNotFound{Pair: ab})
+
+	rw := serve(s, http.MethodGet, "/prices?pair=A/B")
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"error"`)
+}
+
+func TestServer_Models(t *testing.T) {
+	s, g := newTestServer(t)
+	g.On("Models", ab).Return(map[gofer.Pair]*gofer.Model{
+		ab: {
+			Type:       "median",
+			Pair:       ab,
+			Parameters: map[string]string{"minimumSuccessfulSources": "1"},
+			Models:     []*gofer.Model{{Type: "origin", Pair: ab, Parameters: map[string]string{"origin": "x"}}},
+		},
+	}, nil)
+
+	rw := serve(s, http.MethodGet, "/models?pair=A/B")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `[{
+		"type":"median","base":"A","quote":"B","params":{"minimumSuccessfulSources":"1"},
+		"models":[{"type":"origin","base":"A","quote":"B","params":{"origin":"x"}}]
+	}]`, rw.Body.String())
+}
+
+func TestServer_Health(t *testing.T) {
+	s, g := newTestServer(t)
+	g.On("Pairs").Return([]gofer.Pair{ab}, nil).Once()
+	g.On("Pairs").Return([]gofer.Pair(nil), errors.New("err")).Once()
+
+	rw := serve(s, http.MethodGet, "/health")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rw.Body.String())
+
+	rw = serve(s, http.MethodGet, "/health")
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.JSONEq(t, `{"status":"error","error":"err"}`, rw.Body.String())
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	rw := serve(s, http.MethodPost, "/prices")
+	assert.Equal(t, http.StatusMethodNotAllowed, rw.Code)
+}
+
+func TestServer_StartAndStop(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	g := &mocks.Gofer{}
+	g.On("Pairs").Return([]gofer.Pair{ab}, nil)
+	s, err := New(ctx, Config{Gofer: g, Address: "127.0.0.1:0", EnableCORS: true, Logger: null.New()})
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+
+	res, err := http.Get("http://" + s.Addr().String() + "/health")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "*", res.Header.Get("Access-Control-Allow-Origin"))
+
+	ctxCancel()
+	s.Wait()
+}
+
+func TestServer_History(t *testing.T) {
+	s, g := newTestServer(t)
+	ts := time.Unix(1, 0).UTC()
+	g.On("History", ts, ab).Return(map[gofer.Pair][]*gofer.PriceSeries{
+		ab: {
+			{Origin: "a", Pair: ab, Prices: []*gofer.Price{{Type: "origin", Pair: ab, Price: 1, Time: ts}}},
+			{Origin: "b", Pair: ab},
+		},
+	}, nil)
+
+	rw := serve(s, http.MethodGet, "/history?pair=A/B&since=1970-01-01T00:00:01Z")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `{"A/B":[
+		{"origin":"a","base":"A","quote":"B","prices":[
+			{"type":"origin","base":"A","quote":"B","price":1,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:01Z"}
+		]},
+		{"origin":"b","base":"A","quote":"B","prices":[]}
+	]}`, rw.Body.String())
+}
+
+func TestServer_HistoryInvalidSince(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	rw := serve(s, http.MethodGet, "/history?since=yesterday")
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
@@ -0,0 +1,102 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/makerdao/oracle-suite/internal/gofer/marshal"
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+)
+
+// streamPingInterval is an interval in which a comment is sent to keep
+// the connection alive when there are no price updates.
+const streamPingInterval = 30 * time.Second
+
+var errStreamNotSupported = errors.New("price streaming is not supported")
+
+// streamHandler sends price updates using Server-Sent Events. Every update is
+// sent as a "price" event with a price encoded using the marshal.JSON format.
+func (s *Server) streamHandler(rw http.ResponseWriter, r *http.Request) {
+	if !allowGet(rw, r) {
+		return
+	}
+	sg, ok := s.gofer.(gofer.SubscribableGofer)
+	if !ok {
+		writeError(rw, http.StatusNotImplemented, errStreamNotSupported)
+		return
+	}
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		writeError(rw, http.StatusInternalServerError, errStreamNotSupported)
+		return
+	}
+	pairs, err := queryPairs(r)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	// The stream must be closed either when the client disconnects or when
+	// the server is stopped.
+	ctx, ctxCancel := context.WithCancel(r.Context())
+	defer ctxCancel()
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			ctxCancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ch, err := sg.Subscribe(ctx, pairs...)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case price, ok := <-ch:
+			if !ok {
+				return
+			}
+			b, err := marshal.Marshall(marshal.NDJSON, price)
+			if err != nil {
+				s.log.WithError(err).Error("Unable to marshall price")
+				continue
+			}
+			_, _ = rw.Write([]byte("event: price\ndata: "))
+			_, _ = rw.Write(bytes.TrimSpace(b))
+			_, _ = rw.Write([]byte("\n\n"))
+		case <-ticker.C:
+			_, _ = rw.Write([]byte(": ping\n\n"))
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,81 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/gofer"
+	"github.com/makerdao/oracle-suite/pkg/gofer/mocks"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+type subscribableGofer struct {
+	mocks.Gofer
+	pairs []gofer.Pair
+	ch    chan *gofer.Price
+}
+
+func (g *subscribableGofer) Subscribe(ctx context.Context, pairs ...gofer.Pair) (<-chan *gofer.Price, error) {
+	g.pairs = pairs
+	return g.ch, nil
+}
+
+func TestServer_Stream(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	g := &subscribableGofer{ch: make(chan *gofer.Price, 1)}
+	s, err := New(ctx, Config{Gofer: g, Address: "127.0.0.1:0", Logger: null.New()})
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+
+	res, err := http.Get("http://" + s.Addr().String() + "/stream?pair=A/B")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	assert.Equal(t, []gofer.Pair{ab}, g.pairs)
+
+	g.ch <- &gofer.Price{Type: "median", Pair: ab, Price: 1, Time: time.Unix(1, 0)}
+	close(g.ch)
+
+	r := bufio.NewReader(res.Body)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: price\n", line)
+	line, err = r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`data: {"type":"median","base":"A","quote":"B","price":1,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:01Z"}`+"\n",
+		line,
+	)
+}
+
+func TestServer_StreamNotSupported(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	rw := serve(s, http.MethodGet, "/stream")
+	assert.Equal(t, http.StatusNotImplemented, rw.Code)
+}
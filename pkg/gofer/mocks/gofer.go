@@ -17,6 +17,7 @@ package mocks
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -47,6 +48,11 @@ func (g *Gofer) Pairs() ([]gofer.Pair, error) {
 	return args.Get(0).([]gofer.Pair), args.Error(1)
 }
 
+func (g *Gofer) History(since time.Time, pairs ...gofer.Pair) (map[gofer.Pair][]*gofer.PriceSeries, error) {
+	args := g.Called(append([]interface{}{since}, interfaceSlice(pairs)...)...)
+	return args.Get(0).(map[gofer.Pair][]*gofer.PriceSeries), args.Error(1)
+}
+
 func interfaceSlice(slice interface{}) []interface{} {
 	s := reflect.ValueOf(slice)
 	if s.Kind() != reflect.Slice {
@@ -0,0 +1,55 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	fetchDurationMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gofer",
+		Subsystem: "origin",
+		Name:      "fetch_duration_seconds",
+		Help:      "Time spent on fetching prices from an origin.",
+	}, []string{"origin"})
+	fetchErrorsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gofer",
+		Subsystem: "origin",
+		Name:      "fetch_errors_total",
+		Help:      "Number of prices which could not be fetched from an origin.",
+	}, []string{"origin", "pair"})
+	priceMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gofer",
+		Subsystem: "origin",
+		Name:      "price",
+		Help:      "Last price fetched from an origin.",
+	}, []string{"origin", "pair"})
+)
+
+func observeFetch(origin string, duration time.Duration, frs []FetchResult) {
+	fetchDurationMetric.WithLabelValues(origin).Observe(duration.Seconds())
+	for _, fr := range frs {
+		if fr.Error != nil {
+			fetchErrorsMetric.WithLabelValues(origin, fr.Price.Pair.String()).Inc()
+			continue
+		}
+		priceMetric.WithLabelValues(origin, fr.Price.Pair.String()).Set(fr.Price.Price)
+	}
+}
@@ -244,7 +244,9 @@ func (e *Set) Fetch(originPairs map[string][]Pair) map[string][]FetchResult {
 				)
 				mu.Unlock()
 			} else {
+				t := time.Now()
 				resp := handler.Fetch(pairs)
+				observeFetch(origin, time.Since(t), resp)
 				mu.Lock()
 				frs[origin] = append(frs[origin], resp...)
 				mu.Unlock()
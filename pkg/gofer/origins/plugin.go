@@ -0,0 +1,203 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const defaultPluginTimeout = 10 * time.Second
+
+var ErrInvalidPluginConfig = errors.New("either command or address must be provided for the plugin origin")
+
+// Plugin is an origin handler which delegates fetching prices to an external
+// program. It allows to use price sources which cannot be included in this
+// package.
+//
+// The plugin may be either an executable, which is launched for every fetch,
+// or a server listening on a local socket. In both cases, a single JSON
+// encoded PluginRequest, terminated by a new line, is sent to the plugin and
+// a single JSON encoded PluginResponse is expected in reply. Executables
+// receive the request on the standard input and must write the response to
+// the standard output.
+type Plugin struct {
+	// Command is a path to the plugin executable.
+	Command string
+	// Args is a list of arguments passed to the Command.
+	Args []string
+	// Network is a network type of the plugin socket, e.g. "unix" or "tcp".
+	Network string
+	// Address is an address of the plugin socket. If provided, the plugin
+	// is used through the socket and the Command is ignored.
+	Address string
+	// Timeout is a maximum time to wait for a plugin response.
+	Timeout time.Duration
+}
+
+// PluginRequest is a request sent to the plugin.
+type PluginRequest struct {
+	Pairs []PluginPair `json:"pairs"`
+}
+
+// PluginResponse is a response expected from the plugin.
+type PluginResponse struct {
+	// Results is a list of prices for requested pairs. Pairs missing in
+	// the response are considered failed.
+	Results []PluginResult `json:"results"`
+	// Error, if not empty, causes all requested pairs to fail.
+	Error string `json:"error,omitempty"`
+}
+
+type PluginPair struct {
+	Base  string `json:"base"`
+	Quote string `json:"quote"`
+}
+
+type PluginResult struct {
+	Base      string  `json:"base"`
+	Quote     string  `json:"quote"`
+	Price     float64 `json:"price"`
+	Bid       float64 `json:"bid"`
+	Ask       float64 `json:"ask"`
+	Volume24h float64 `json:"volume24h"`
+	// Timestamp is a unix timestamp of the price. If empty, the time
+	// of receiving the response is used.
+	Timestamp int64  `json:"timestamp"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (p Plugin) PullPrices(pairs []Pair) []FetchResult {
+	req := PluginRequest{}
+	for _, pair := range pairs {
+		req.Pairs = append(req.Pairs, PluginPair{Base: pair.Base, Quote: pair.Quote})
+	}
+	reqJSON, err := json.Marshal(req)
+	if err != nil {
+		return fetchResultListWithErrors(pairs, err)
+	}
+	reqJSON = append(reqJSON, '\n')
+
+	var resJSON []byte
+	switch {
+	case p.Address != "":
+		resJSON, err = p.callSocket(reqJSON)
+	case p.Command != "":
+		resJSON, err = p.callCommand(reqJSON)
+	default:
+		err = ErrInvalidPluginConfig
+	}
+	if err != nil {
+		return fetchResultListWithErrors(pairs, err)
+	}
+
+	var res PluginResponse
+	if err = json.Unmarshal(resJSON, &res); err != nil {
+		return fetchResultListWithErrors(pairs, fmt.Errorf("%w: %s", ErrInvalidResponse, err))
+	}
+	if res.Error != "" {
+		return fetchResultListWithErrors(pairs, errors.New(res.Error))
+	}
+
+	return p.mapResults(pairs, res.Results)
+}
+
+func (p Plugin) mapResults(pairs []Pair, results []PluginResult) []FetchResult {
+	now := time.Now()
+	frs := make([]FetchResult, len(pairs))
+	for i, pair := range pairs {
+		frs[i] = fetchResultWithError(pair, ErrMissingResponseForPair)
+		for _, r := range results {
+			if !strings.EqualFold(r.Base, pair.Base) || !strings.EqualFold(r.Quote, pair.Quote) {
+				continue
+			}
+			if r.Error != "" {
+				frs[i] = fetchResultWithError(pair, errors.New(r.Error))
+				break
+			}
+			ts := now
+			if r.Timestamp > 0 {
+				ts = time.Unix(r.Timestamp, 0)
+			}
+			frs[i] = fetchResult(Price{
+				Pair:      pair,
+				Price:     r.Price,
+				Bid:       r.Bid,
+				Ask:       r.Ask,
+				Volume24h: r.Volume24h,
+				Timestamp: ts,
+			})
+			break
+		}
+	}
+	return frs
+}
+
+func (p Plugin) callCommand(req []byte) ([]byte, error) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), p.timeout())
+	defer ctxCancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("plugin %s failed: %w: %s", p.Command, err, strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("plugin %s failed: %w", p.Command, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func (p Plugin) callSocket(req []byte) ([]byte, error) {
+	network := p.Network
+	if network == "" {
+		network = "unix"
+	}
+	conn, err := net.DialTimeout(network, p.Address, p.timeout())
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to plugin at %s: %w", p.Address, err)
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(p.timeout())); err != nil {
+		return nil, err
+	}
+	if _, err = conn.Write(req); err != nil {
+		return nil, fmt.Errorf("unable to send request to plugin at %s: %w", p.Address, err)
+	}
+	var res json.RawMessage
+	if err = json.NewDecoder(conn).Decode(&res); err != nil {
+		return nil, fmt.Errorf("unable to read response from plugin at %s: %w", p.Address, err)
+	}
+	return res, nil
+}
+
+func (p Plugin) timeout() time.Duration {
+	if p.Timeout <= 0 {
+		return defaultPluginTimeout
+	}
+	return p.Timeout
+}
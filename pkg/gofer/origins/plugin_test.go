@@ -0,0 +1,152 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const pluginHelperEnv = "GOFER_TEST_PLUGIN_HELPER"
+
+// pluginHelper answers a plugin request read from r. Pair "ERR/ERR" fails,
+// "SKIP/SKIP" is missing from the response and other pairs are priced at 1.
+func pluginHelper(r io.Reader, w io.Writer) {
+	var req PluginRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		_ = json.NewEncoder(w).Encode(PluginResponse{Error: err.Error()})
+		return
+	}
+	res := PluginResponse{}
+	for _, p := range req.Pairs {
+		switch p.Base {
+		case "ERR":
+			res.Results = append(res.Results, PluginResult{Base: p.Base, Quote: p.Quote, Error: "failed"})
+		case "SKIP":
+		default:
+			res.Results = append(res.Results, PluginResult{Base: p.Base, Quote: p.Quote, Price: 1, Timestamp: 1})
+		}
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func TestMain(m *testing.M) {
+	// The test binary is used as a plugin executable by the PluginSuite.
+	if os.Getenv(pluginHelperEnv) != "" {
+		pluginHelper(os.Stdin, os.Stdout)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type PluginSuite struct {
+	suite.Suite
+	listener net.Listener
+	origin   *BaseExchangeHandler
+}
+
+func (suite *PluginSuite) Origin() Handler {
+	return suite.origin
+}
+
+func (suite *PluginSuite) SetupSuite() {
+	var err error
+	suite.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	go func() {
+		for {
+			conn, err := suite.listener.Accept()
+			if err != nil {
+				return
+			}
+			pluginHelper(conn, conn)
+			conn.Close()
+		}
+	}()
+}
+
+func (suite *PluginSuite) TearDownSuite() {
+	suite.listener.Close()
+}
+
+func (suite *PluginSuite) assertResults(frs []FetchResult) {
+	suite.Len(frs, 3)
+	suite.NoError(frs[0].Error)
+	suite.Equal(Pair{Base: "A", Quote: "B"}, frs[0].Price.Pair)
+	suite.Equal(1.0, frs[0].Price.Price)
+	suite.Equal(int64(1), frs[0].Price.Timestamp.Unix())
+	suite.EqualError(frs[1].Error, "failed")
+	suite.ErrorIs(frs[2].Error, ErrMissingResponseForPair)
+}
+
+func (suite *PluginSuite) TestSocket() {
+	suite.origin = NewBaseExchangeHandler(Plugin{
+		Network: "tcp",
+		Address: suite.listener.Addr().String(),
+	}, nil)
+	suite.assertResults(suite.origin.Fetch([]Pair{
+		{Base: "A", Quote: "B"},
+		{Base: "ERR", Quote: "ERR"},
+		{Base: "SKIP", Quote: "SKIP"},
+	}))
+}
+
+func (suite *PluginSuite) TestCommand() {
+	suite.Require().NoError(os.Setenv(pluginHelperEnv, "1"))
+	defer os.Unsetenv(pluginHelperEnv)
+	suite.origin = NewBaseExchangeHandler(Plugin{
+		Command: os.Args[0],
+	}, nil)
+	suite.assertResults(suite.origin.Fetch([]Pair{
+		{Base: "A", Quote: "B"},
+		{Base: "ERR", Quote: "ERR"},
+		{Base: "SKIP", Quote: "SKIP"},
+	}))
+}
+
+func (suite *PluginSuite) TestAliases() {
+	suite.origin = NewBaseExchangeHandler(Plugin{
+		Network: "tcp",
+		Address: suite.listener.Addr().String(),
+	}, SymbolAliases{"A": "C"})
+	frs := suite.origin.Fetch([]Pair{{Base: "A", Quote: "B"}})
+	suite.NoError(frs[0].Error)
+	suite.Equal(Pair{Base: "A", Quote: "B"}, frs[0].Price.Pair)
+}
+
+func (suite *PluginSuite) TestUnreachable() {
+	suite.origin = NewBaseExchangeHandler(Plugin{
+		Network: "unix",
+		Address: "/nonexistent/plugin.sock",
+	}, nil)
+	frs := suite.origin.Fetch([]Pair{{Base: "A", Quote: "B"}})
+	suite.Error(frs[0].Error)
+}
+
+func (suite *PluginSuite) TestMissingConfig() {
+	suite.origin = NewBaseExchangeHandler(Plugin{}, nil)
+	frs := suite.origin.Fetch([]Pair{{Base: "A", Quote: "B"}})
+	suite.ErrorIs(frs[0].Error, ErrInvalidPluginConfig)
+}
+
+func TestPluginSuite(t *testing.T) {
+	suite.Run(t, new(PluginSuite))
+}
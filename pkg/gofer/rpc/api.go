@@ -16,6 +16,9 @@
 package rpc
 
 import (
+	"errors"
+	"time"
+
 	"github.com/makerdao/oracle-suite/internal/gofer/marshal"
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 	"github.com/makerdao/oracle-suite/pkg/gofer/graph/feeder"
@@ -24,6 +27,8 @@ import (
 
 type Nothing = struct{}
 
+var ErrHistoryNotSupported = errors.New("gofer does not support price history")
+
 type API struct {
 	gofer gofer.Gofer
 	log   log.Logger
@@ -54,6 +59,15 @@ type PricesResp struct {
 	Prices map[gofer.Pair]*gofer.Price
 }
 
+type HistoryArg struct {
+	Since time.Time
+	Pairs []gofer.Pair
+}
+
+type HistoryResp struct {
+	Series map[gofer.Pair][]*gofer.PriceSeries
+}
+
 type PairsResp struct {
 	Pairs []gofer.Pair
 }
@@ -78,6 +92,20 @@ func (n *API) Prices(arg *PricesArg, resp *PricesResp) error {
 	return nil
 }
 
+func (n *API) History(arg *HistoryArg, resp *HistoryResp) error {
+	n.log.WithField("pairs", arg.Pairs).WithField("since", arg.Since).Info("History")
+	g, ok := n.gofer.(gofer.HistoricalGofer)
+	if !ok {
+		return ErrHistoryNotSupported
+	}
+	series, err := g.History(arg.Since, arg.Pairs...)
+	if err != nil {
+		return err
+	}
+	resp.Series = series
+	return nil
+}
+
 func (n *API) Pairs(_ *Nothing, resp *PairsResp) error {
 	n.log.Info("Prices")
 	pairs, err := n.gofer.Pairs()
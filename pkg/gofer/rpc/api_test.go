@@ -19,6 +19,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -106,3 +107,19 @@ func TestClient_Pairs(t *testing.T) {
 	assert.Equal(t, pairs, resp)
 	assert.NoError(t, err)
 }
+
+func TestClient_History(t *testing.T) {
+	pair := gofer.Pair{Base: "A", Quote: "B"}
+	since := time.Unix(1, 0).UTC()
+	series := map[gofer.Pair][]*gofer.PriceSeries{pair: {{
+		Origin: "a",
+		Pair:   pair,
+		Prices: []*gofer.Price{{Type: "origin", Pair: pair, Price: 1, Time: since}},
+	}}}
+
+	mockGofer.On("History", since, pair).Return(series, nil)
+	resp, err := rpcGofer.History(since, pair)
+
+	assert.Equal(t, series, resp)
+	assert.NoError(t, err)
+}
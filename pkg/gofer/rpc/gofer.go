@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"net/rpc"
+	"time"
 
 	"github.com/makerdao/oracle-suite/pkg/gofer"
 )
@@ -104,6 +105,19 @@ func (g *Gofer) Prices(pairs ...gofer.Pair) (map[gofer.Pair]*gofer.Price, error)
 	return resp.Prices, nil
 }
 
+// History implements the gofer.HistoricalGofer interface.
+func (g *Gofer) History(since time.Time, pairs ...gofer.Pair) (map[gofer.Pair][]*gofer.PriceSeries, error) {
+	if g.rpc == nil {
+		return nil, ErrNotStarted
+	}
+	resp := &HistoryResp{}
+	err := g.rpc.Call("API.History", HistoryArg{Since: since, Pairs: pairs}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Series, nil
+}
+
 // Pairs implements the gofer.Gofer interface.
 func (g *Gofer) Pairs() ([]gofer.Pair, error) {
 	if g.rpc == nil {
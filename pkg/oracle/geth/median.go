@@ -30,8 +30,6 @@ import (
 
 var ErrStorageQueryFailed = errors.New("oracle contract storage query failed")
 
-// TODO: make it configurable
-const gasLimit = 200000
 const maxReadRetries = 3
 const delayBetweenReadRetries = 5 * time.Second
 
@@ -147,34 +145,24 @@ func (m *Median) Feeds(ctx context.Context) ([]ethereum.Address, error) {
 
 // Poke implements the oracle.Median interface.
 func (m *Median) Poke(ctx context.Context, prices []*oracle.Price, simulateBeforeRun bool) (*ethereum.Hash, error) {
-	// It's important to send prices in correct order, otherwise contract will fail:
-	sort.Slice(prices, func(i, j int) bool {
-		return prices[i].Val.Cmp(prices[j].Val) < 0
-	})
-
-	var (
-		val []*big.Int
-		age []*big.Int
-		v   []uint8
-		r   [][32]byte
-		s   [][32]byte
-	)
-
-	for _, arg := range prices {
-		val = append(val, arg.Val)
-		age = append(age, big.NewInt(arg.Age.Unix()))
-		v = append(v, arg.V)
-		r = append(r, arg.R)
-		s = append(s, arg.S)
-	}
-
+	args := pokeArgs(prices)
 	if simulateBeforeRun {
-		if _, err := m.read(ctx, "poke", val, age, v, r, s); err != nil {
+		if _, err := m.read(ctx, "poke", args...); err != nil {
 			return nil, err
 		}
 	}
 
-	return m.write(ctx, "poke", val, age, v, r, s)
+	return m.write(ctx, "poke", args...)
+}
+
+// PokeCall implements the oracle.Median interface.
+func (m *Median) PokeCall(prices []*oracle.Price) (ethereum.Call, error) {
+	cd, err := medianABI.Pack("poke", pokeArgs(prices)...)
+	if err != nil {
+		return ethereum.Call{}, err
+	}
+
+	return ethereum.Call{Address: m.address, Data: cd}, nil
 }
 
 // Lift implements the oracle.Median interface.
@@ -234,11 +222,37 @@ func (m *Median) write(ctx context.Context, method string, args ...interface{})
 		return nil, err
 	}
 
+	// The gas limit is estimated by the client:
 	return m.ethereum.SendTransaction(ctx, &ethereum.Transaction{
-		Address:  m.address,
-		GasLimit: new(big.Int).SetUint64(gasLimit),
-		Data:     cd,
+		Address: m.address,
+		Data:    cd,
+	})
+}
+
+// pokeArgs returns arguments for the poke method.
+func pokeArgs(prices []*oracle.Price) []interface{} {
+	// It's important to send prices in correct order, otherwise contract will fail:
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Val.Cmp(prices[j].Val) < 0
 	})
+
+	var (
+		val []*big.Int
+		age []*big.Int
+		v   []uint8
+		r   [][32]byte
+		s   [][32]byte
+	)
+
+	for _, arg := range prices {
+		val = append(val, arg.Val)
+		age = append(age, big.NewInt(arg.Age.Unix()))
+		v = append(v, arg.V)
+		r = append(r, arg.R)
+		s = append(s, arg.S)
+	}
+
+	return []interface{}{val, age, v, r, s}
 }
 
 func retry(maxRetries int, delay time.Duration, f func() error) error {
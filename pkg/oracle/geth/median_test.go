@@ -162,7 +162,31 @@ func TestMedian_Poke(t *testing.T) {
 
 	assert.Equal(t, a, tx.Address)
 	assert.Equal(t, (*big.Int)(nil), tx.MaxFee)
-	assert.Equal(t, big.NewInt(gasLimit), tx.GasLimit)
+	assert.Equal(t, (*big.Int)(nil), tx.GasLimit)
 	assert.Equal(t, uint64(0), tx.Nonce)
 	assert.Equal(t, cd, hex.EncodeToString(tx.Data))
 }
+
+func TestMedian_PokeCall(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	a := ethereum.HexToAddress("0x1122344556677889900112233445566778899002")
+	m := NewMedian(c, a)
+
+	p1 := &oracle.Price{Wat: "AAABBB", Age: time.Unix(0xAAAAAAAA, 0)}
+	p1.SetFloat64Price(20)
+	p2 := &oracle.Price{Wat: "AAABBB", Age: time.Unix(0xBBBBBBBB, 0)}
+	p2.SetFloat64Price(10)
+
+	c.On("SendTransaction", mock.Anything, mock.Anything).Return(&ethereum.Hash{}, nil)
+
+	// PokeCall must produce the same call data as the Poke function:
+	call, err := m.PokeCall([]*oracle.Price{p1, p2})
+	assert.NoError(t, err)
+	_, err = m.Poke(context.Background(), []*oracle.Price{p1, p2}, false)
+	assert.NoError(t, err)
+
+	tx := c.Calls[0].Arguments.Get(1).(*ethereum.Transaction)
+	assert.Equal(t, a, call.Address)
+	assert.Equal(t, tx.Data, call.Data)
+}
@@ -0,0 +1,139 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+var ErrCallReverted = errors.New("call reverted")
+
+// Multicall implements the oracle.Multicall interface using go-ethereum
+// packages. It uses the tryAggregate method of the Multicall2 contract.
+type Multicall struct {
+	ethereum ethereum.Client
+	address  ethereum.Address
+}
+
+// NewMulticall creates the new Multicall instance.
+func NewMulticall(ethereum ethereum.Client, address ethereum.Address) *Multicall {
+	return &Multicall{
+		ethereum: ethereum,
+		address:  address,
+	}
+}
+
+// Address implements the oracle.Multicall interface.
+func (m *Multicall) Address() common.Address {
+	return m.address
+}
+
+// Send implements the oracle.Multicall interface.
+func (m *Multicall) Send(ctx context.Context, calls []ethereum.Call) (*ethereum.Hash, []error, error) {
+	if len(calls) == 0 {
+		return nil, nil, nil
+	}
+
+	// Simulate all calls to find out which of them would fail:
+	cd, err := tryAggregateCallData(false, calls)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := m.ethereum.Call(ctx, ethereum.Call{Address: m.address, Data: cd})
+	if err != nil {
+		return nil, nil, err
+	}
+	var results []struct {
+		Success    bool
+		ReturnData []byte
+	}
+	if err := multicallABI.UnpackIntoInterface(&results, "tryAggregate", data); err != nil {
+		return nil, nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, nil, fmt.Errorf("unexpected number of results: %d, expected %d", len(results), len(calls))
+	}
+
+	// Send only those calls that succeeded during the simulation:
+	var valid []ethereum.Call
+	errs := make([]error, len(calls))
+	for n, r := range results {
+		if !r.Success {
+			errs[n] = revertError(r.ReturnData)
+			continue
+		}
+		valid = append(valid, calls[n])
+	}
+	if len(valid) == 0 {
+		return nil, errs, nil
+	}
+	// Calls may still fail when the transaction is mined, for example if
+	// another relayer updated the contract in the meantime. The remaining
+	// calls are sent with the requireSuccess flag, so a call cannot fail
+	// without reverting the whole transaction. Otherwise, the transaction
+	// would be reported as successful for every call in it.
+	cd, err = tryAggregateCallData(true, valid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The gas limit is estimated by the client:
+	hash, err := m.ethereum.SendTransaction(ctx, &ethereum.Transaction{
+		Address: m.address,
+		Data:    cd,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hash, errs, nil
+}
+
+// tryAggregateCallData returns call data for the tryAggregate method. If
+// requireSuccess is false, a single failing call does not revert the other
+// ones.
+func tryAggregateCallData(requireSuccess bool, calls []ethereum.Call) ([]byte, error) {
+	type abiCall struct {
+		Address common.Address `abi:"target"`
+		Data    []byte         `abi:"callData"`
+	}
+	var abiCalls []abiCall
+	for _, c := range calls {
+		abiCalls = append(abiCalls, abiCall{
+			Address: c.Address,
+			Data:    c.Data,
+		})
+	}
+
+	return multicallABI.Pack("tryAggregate", requireSuccess, abiCalls)
+}
+
+// revertError returns an error with the revert reason decoded from the
+// return data of a failed call.
+func revertError(data []byte) error {
+	reason, err := abi.UnpackRevert(data)
+	if err != nil {
+		return ErrCallReverted
+	}
+	return fmt.Errorf("%w: %s", ErrCallReverted, reason)
+}
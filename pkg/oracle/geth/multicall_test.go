@@ -0,0 +1,116 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+)
+
+type testMulticallResult struct {
+	Success    bool   `abi:"success"`
+	ReturnData []byte `abi:"returnData"`
+}
+
+func packTryAggregateResults(t *testing.T, results []testMulticallResult) []byte {
+	b, err := multicallABI.Methods["tryAggregate"].Outputs.Pack(results)
+	require.NoError(t, err)
+	return b
+}
+
+func packRevertReason(t *testing.T, reason string) []byte {
+	typ, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	b, err := abi.Arguments{{Type: typ}}.Pack(reason)
+	require.NoError(t, err)
+	return append([]byte{0x08, 0xc3, 0x79, 0xa0}, b...)
+}
+
+func TestMulticall_Send(t *testing.T) {
+	c := &mocks.Client{}
+	a := ethereum.HexToAddress("0x1122344556677889900112233445566778899002")
+	m := NewMulticall(c, a)
+
+	calls := []ethereum.Call{
+		{Address: ethereum.HexToAddress("0x01"), Data: []byte{1}},
+		{Address: ethereum.HexToAddress("0x02"), Data: []byte{2}},
+		{Address: ethereum.HexToAddress("0x03"), Data: []byte{3}},
+	}
+
+	simulateCD, err := tryAggregateCallData(false, calls)
+	require.NoError(t, err)
+	sendCD, err := tryAggregateCallData(true, []ethereum.Call{calls[0], calls[2]})
+	require.NoError(t, err)
+
+	c.On("Call", mock.Anything, ethereum.Call{Address: a, Data: simulateCD}).Return(
+		packTryAggregateResults(t, []testMulticallResult{
+			{Success: true},
+			{Success: false, ReturnData: packRevertReason(t, "median/stale-message")},
+			{Success: true},
+		}),
+		nil,
+	)
+	c.On("SendTransaction", mock.Anything, &ethereum.Transaction{Address: a, Data: sendCD}).Return(
+		&ethereum.Hash{0x01},
+		nil,
+	)
+
+	hash, errs, err := m.Send(context.Background(), calls)
+	require.NoError(t, err)
+	assert.Equal(t, &ethereum.Hash{0x01}, hash)
+	require.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.True(t, errors.Is(errs[1], ErrCallReverted))
+	assert.Contains(t, errs[1].Error(), "median/stale-message")
+	assert.NoError(t, errs[2])
+	c.AssertExpectations(t)
+}
+
+func TestMulticall_Send_AllFailed(t *testing.T) {
+	c := &mocks.Client{}
+	a := ethereum.HexToAddress("0x1122344556677889900112233445566778899002")
+	m := NewMulticall(c, a)
+
+	calls := []ethereum.Call{
+		{Address: ethereum.HexToAddress("0x01"), Data: []byte{1}},
+		{Address: ethereum.HexToAddress("0x02"), Data: []byte{2}},
+	}
+
+	c.On("Call", mock.Anything, mock.Anything).Return(
+		packTryAggregateResults(t, []testMulticallResult{
+			{Success: false},
+			{Success: false},
+		}),
+		nil,
+	)
+
+	hash, errs, err := m.Send(context.Background(), calls)
+	require.NoError(t, err)
+	assert.Nil(t, hash)
+	require.Len(t, errs, 2)
+	assert.True(t, errors.Is(errs[0], ErrCallReverted))
+	assert.True(t, errors.Is(errs[1], ErrCallReverted))
+	c.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,118 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+)
+
+func TestOSM_Peek(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	a := ethereum.Address{}
+	o := NewOSM(c, a)
+
+	// Call Peek function:
+	bts := make([]byte, 64)
+	big.NewInt(123456).FillBytes(bts[:32])
+	bts[63] = 1
+	c.On("Call", mock.Anything, mock.Anything).Return(bts, nil)
+	val, has, err := o.Peek(context.Background())
+
+	// Verify:
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.Equal(t, big.NewInt(123456), val)
+}
+
+func TestOSM_Zzz(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	a := ethereum.Address{}
+	o := NewOSM(c, a)
+
+	// Call Zzz function:
+	bts := make([]byte, 32)
+	big.NewInt(1620000000).FillBytes(bts)
+	c.On("Call", mock.Anything, mock.Anything).Return(bts, nil)
+	zzz, err := o.Zzz(context.Background())
+
+	// Verify:
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1620000000, 0), zzz)
+}
+
+func TestOSM_Hop(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	a := ethereum.Address{}
+	o := NewOSM(c, a)
+
+	// Call Hop function:
+	bts := make([]byte, 32)
+	big.NewInt(3600).FillBytes(bts)
+	c.On("Call", mock.Anything, mock.Anything).Return(bts, nil)
+	hop, err := o.Hop(context.Background())
+
+	// Verify:
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, hop)
+}
+
+func TestOSM_Pass(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	a := ethereum.Address{}
+	o := NewOSM(c, a)
+
+	// Call Pass function:
+	bts := make([]byte, 32)
+	bts[31] = 1
+	c.On("Call", mock.Anything, mock.Anything).Return(bts, nil)
+	pass, err := o.Pass(context.Background())
+
+	// Verify:
+	assert.NoError(t, err)
+	assert.True(t, pass)
+}
+
+func TestOSM_Poke(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	a := ethereum.HexToAddress("0x1122344556677889900112233445566778899002")
+	o := NewOSM(c, a)
+
+	c.On("SendTransaction", mock.Anything, mock.Anything).Return(&ethereum.Hash{}, nil)
+
+	// Call Poke function:
+	_, err := o.Poke(context.Background(), false)
+	assert.NoError(t, err)
+
+	// Verify generated transaction:
+	tx := c.Calls[0].Arguments.Get(1).(*ethereum.Transaction)
+	assert.Equal(t, a, tx.Address)
+	assert.Equal(t, "18178358", hex.EncodeToString(tx.Data))
+}
@@ -51,6 +51,10 @@ type Median interface {
 	// set to true, then transaction will be simulated on the EVM before actual
 	// transaction will be send.
 	Poke(ctx context.Context, prices []*Price, simulateBeforeRun bool) (*ethereum.Hash, error)
+	// PokeCall returns the call which invokes contract's poke method without
+	// sending it. It may be used to send multiple pokes in a single
+	// transaction using the Multicall contract.
+	PokeCall(prices []*Price) (ethereum.Call, error)
 	// Lift sends transaction to the smart contract which invokes contract's
 	// lift method, which sends  adds given addresses to the feeders list (orcls).
 	// If simulateBeforeRun is set to true, then transaction will be simulated
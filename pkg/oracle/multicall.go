@@ -0,0 +1,37 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oracle
+
+import (
+	"context"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+// Multicall is an interface for the contract which executes multiple calls
+// in a single transaction, like the Multicall2 contract:
+// https://github.com/makerdao/multicall
+type Multicall interface {
+	// Address returns multicall contract address.
+	Address() ethereum.Address
+	// Send simulates given calls and sends the ones that did not fail in a
+	// single transaction. If any of the sent calls fails when the
+	// transaction is mined, the whole transaction is reverted, so its
+	// receipt is valid for every call in it. The returned slice contains an error for every call that failed during
+	// the simulation, or nil for calls that were sent, in the same order as
+	// given calls. If none of the calls succeeded, the hash is nil.
+	Send(ctx context.Context, calls []ethereum.Call) (*ethereum.Hash, []error, error)
+}
@@ -0,0 +1,54 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+// OSM is an interface for the Oracle Security Module contract:
+// https://github.com/makerdao/osm
+//
+// Contract documentation:
+// https://docs.makerdao.com/smart-contract-modules/oracle-module/oracle-security-module-osm-detailed-documentation
+type OSM interface {
+	// Address returns OSM contract address.
+	Address() ethereum.Address
+	// Peek returns the current price and a flag indicating whether the
+	// price is valid. The caller must be whitelisted in the contract.
+	Peek(ctx context.Context) (*big.Int, bool, error)
+	// Peep returns the next price and a flag indicating whether the price
+	// is valid. The caller must be whitelisted in the contract.
+	Peep(ctx context.Context) (*big.Int, bool, error)
+	// Zzz returns the time of the last update, rounded down to the nearest
+	// multiple of hop.
+	Zzz(ctx context.Context) (time.Time, error)
+	// Hop returns the minimum time between updates.
+	Hop(ctx context.Context) (time.Duration, error)
+	// Pass returns true if the hop has passed since the last update and
+	// the contract can be poked.
+	Pass(ctx context.Context) (bool, error)
+	// Poke sends transaction to the smart contract which invokes contract's
+	// poke method, which moves the next price to the current one and reads
+	// the new next price from the source Median contract. If
+	// simulateBeforeRun is set to true, then transaction will be simulated
+	// on the EVM before actual transaction will be send.
+	Poke(ctx context.Context, simulateBeforeRun bool) (*ethereum.Hash, error)
+}
@@ -27,11 +27,17 @@ import (
 
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/log"
+	"github.com/makerdao/oracle-suite/pkg/starkex"
 )
 
 const PriceMultiplier = 1e18
 
+// StarkOracleName is the oracle name used to create StarkEx price messages.
+const StarkOracleName = "Maker"
+
 var ErrPriceNotSet = errors.New("unable to sign a price because the price is not set")
+var ErrInvalidStarkSignature = errors.New("invalid stark signature")
+var ErrStarkPriceOutOfRange = errors.New("price cannot be represented in a stark price message")
 var ErrUnmarshallingFailure = errors.New("unable to unmarshal given JSON")
 
 func errUnmarshalling(s string, err error) error {
@@ -107,6 +113,62 @@ func (p *Price) Sign(signer ethereum.Signer) error {
 	return nil
 }
 
+// SignStark adds the StarkEx compatible signature to the price.
+func (p *Price) SignStark(key *starkex.PrivateKey) error {
+	if p.Val == nil {
+		return ErrPriceNotSet
+	}
+
+	hash, err := p.starkHash()
+	if err != nil {
+		return err
+	}
+	r, s, err := key.Sign(hash)
+	if err != nil {
+		return err
+	}
+
+	p.StarkR = r.Bytes()
+	p.StarkS = s.Bytes()
+	p.StarkPK = key.PublicKey().Bytes()
+
+	return nil
+}
+
+// HasStarkSignature returns true if the price contains the StarkEx
+// signature.
+func (p *Price) HasStarkSignature() bool {
+	return len(p.StarkR) != 0 || len(p.StarkS) != 0 || len(p.StarkPK) != 0
+}
+
+// VerifyStark verifies the StarkEx signature of the price using the given
+// public key. The public key included in the price is not covered by the
+// price signature, so it must not be trusted. The public key must be
+// obtained from a trusted source, e.g. the feeder's configuration.
+func (p *Price) VerifyStark(publicKey *big.Int) error {
+	if p.Val == nil {
+		return ErrPriceNotSet
+	}
+	if len(p.StarkPK) != 0 && new(big.Int).SetBytes(p.StarkPK).Cmp(publicKey) != 0 {
+		return ErrInvalidStarkSignature
+	}
+
+	hash, err := p.starkHash()
+	if err != nil {
+		return err
+	}
+	if !starkex.Verify(
+		publicKey,
+		hash,
+		new(big.Int).SetBytes(p.StarkR),
+		new(big.Int).SetBytes(p.StarkS),
+	) {
+		return ErrInvalidStarkSignature
+	}
+
+	return nil
+}
+
 func (p *Price) Signature() ethereum.Signature {
 	return ethereum.SignatureFromVRS(p.V, p.R, p.S)
 }
@@ -240,3 +302,24 @@ func (p *Price) hash() []byte {
 
 	return ethereum.SHA3Hash(hash)
 }
+
+// starkHash is the StarkEx price message hash:
+//   pedersen(asset << 40 | oracle, val << 32 | age)
+// where asset is the 16 bytes long, right padded asset name and oracle is
+// the 5 bytes long oracle name.
+func (p *Price) starkHash() (*big.Int, error) {
+	if len(p.Wat) > 16 || p.Val.Sign() < 0 || p.Val.BitLen() > 120 || p.Age.Unix() < 0 || p.Age.Unix() >= 1<<32 {
+		return nil, ErrStarkPriceOutOfRange
+	}
+
+	asset := make([]byte, 16)
+	copy(asset, p.Wat)
+	oracle := make([]byte, 5)
+	copy(oracle, StarkOracleName)
+
+	a := new(big.Int).SetBytes(append(asset, oracle...))
+	b := new(big.Int).Lsh(p.Val, 32)
+	b.Or(b, big.NewInt(p.Age.Unix()))
+
+	return starkex.PedersenHash(a, b)
+}
@@ -19,13 +19,16 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"math"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+	"github.com/makerdao/oracle-suite/pkg/starkex"
 )
 
 // Hash for the AAABBB asset pair, with the price set to 42 and the age to 1605371361:
@@ -102,6 +105,44 @@ func TestPrice_Sign_NoPrice(t *testing.T) {
 	assert.Equal(t, ErrPriceNotSet, err)
 }
 
+func TestPrice_SignStark(t *testing.T) {
+	key, err := starkex.DerivePrivateKey([]byte("seed"))
+	require.NoError(t, err)
+
+	p := &Price{Wat: "AAABBB"}
+	p.Age = time.Unix(1605371361, 0)
+	p.SetFloat64Price(42)
+
+	require.NoError(t, p.SignStark(key))
+	assert.True(t, p.HasStarkSignature())
+	assert.Equal(t, key.PublicKey().Bytes(), p.StarkPK)
+	assert.NoError(t, p.VerifyStark(key.PublicKey()))
+
+	// The signature must not be valid for another key, even if the key
+	// in the price is replaced:
+	other, err := starkex.DerivePrivateKey([]byte("other"))
+	require.NoError(t, err)
+	assert.Equal(t, ErrInvalidStarkSignature, p.VerifyStark(other.PublicKey()))
+	p.StarkPK = other.PublicKey().Bytes()
+	assert.Equal(t, ErrInvalidStarkSignature, p.VerifyStark(other.PublicKey()))
+	p.StarkPK = key.PublicKey().Bytes()
+
+	// Any change in the price must invalidate the signature:
+	p.SetFloat64Price(43)
+	assert.Equal(t, ErrInvalidStarkSignature, p.VerifyStark(key.PublicKey()))
+}
+
+func TestPrice_SignStark_OutOfRange(t *testing.T) {
+	key, err := starkex.DerivePrivateKey([]byte("seed"))
+	require.NoError(t, err)
+
+	p := &Price{Wat: "AAABBB"}
+	p.Age = time.Unix(1605371361, 0)
+	p.Val = new(big.Int).Lsh(big.NewInt(1), 120)
+
+	assert.Equal(t, ErrStarkPriceOutOfRange, p.SignStark(key))
+}
+
 func TestPrice_Marshall(t *testing.T) {
 	p := &Price{Wat: "AAABBB"}
 	p.Age = time.Unix(1605371361, 0)
@@ -0,0 +1,272 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/log"
+	"github.com/makerdao/oracle-suite/pkg/transport"
+	"github.com/makerdao/oracle-suite/pkg/transport/messages"
+)
+
+const CoordinatorLoggerTag = "SPECTRE_COORDINATOR"
+
+const defaultSlotDuration = time.Minute
+const defaultFallbackDelay = 20 * time.Second
+
+// maxIntentClockSkew is the maximum allowed difference between the
+// timestamp of a received intent and the local time. Intents from further
+// in the future would block other relayers for longer than a slot.
+const maxIntentClockSkew = 10 * time.Second
+
+var errUnknownRelayer = errors.New("relayer is not in the relayer set")
+var errInvalidIntentSignature = errors.New("received relayer intent has an invalid signature")
+var errIntentFromFuture = errors.New("received relayer intent has a timestamp from the future")
+var errFallbackDelayTooLong = errors.New("fallback delay is too long for the number of relayers and the slot duration")
+
+// Coordinator coordinates multiple Spectre instances, so that only one of
+// them sends a poke transaction for a given pair at a time.
+//
+// Time is divided into slots. In every slot, the relayers are ordered
+// deterministically by the hash of their address, the asset pair and
+// the slot number. The first relayer in that order is allowed to poke
+// immediately, the next one after the fallback delay, and so on. Before
+// sending a transaction, a relayer announces its intent to other relayers,
+// which then refrain from poking the same pair for the slot duration.
+type Coordinator struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	doneCh chan struct{}
+
+	signer        ethereum.Signer
+	transport     transport.Transport
+	relayers      []ethereum.Address
+	slotDuration  time.Duration
+	fallbackDelay time.Duration
+	intents       map[string]map[ethereum.Address]time.Time
+	log           log.Logger
+}
+
+type CoordinatorConfig struct {
+	// Signer is used to sign and verify relayer intents. The signer's
+	// address must be in the relayer set.
+	Signer ethereum.Signer
+	// Transport is used to exchange intents with other relayers.
+	Transport transport.Transport
+	// Relayers is the list of addresses of all coordinated relayers.
+	Relayers []ethereum.Address
+	// SlotDuration is the duration of a single time slot. If zero, one
+	// minute is used.
+	SlotDuration time.Duration
+	// FallbackDelay is the time after which the next relayer in the order
+	// is allowed to poke if the previous one remained silent. If zero,
+	// 20 seconds is used. The last relayer in the order must be able to
+	// poke within the slot, so the delay multiplied by the number of other
+	// relayers must be shorter than the slot duration.
+	FallbackDelay time.Duration
+	// Logger is a current logger interface used by the Coordinator.
+	Logger log.Logger
+}
+
+func NewCoordinator(ctx context.Context, cfg CoordinatorConfig) (*Coordinator, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	if !containsAddress(cfg.Relayers, cfg.Signer.Address()) {
+		return nil, errUnknownRelayer
+	}
+	c := &Coordinator{
+		ctx:           ctx,
+		doneCh:        make(chan struct{}),
+		signer:        cfg.Signer,
+		transport:     cfg.Transport,
+		relayers:      cfg.Relayers,
+		slotDuration:  cfg.SlotDuration,
+		fallbackDelay: cfg.FallbackDelay,
+		intents:       make(map[string]map[ethereum.Address]time.Time),
+		log:           cfg.Logger.WithField("tag", CoordinatorLoggerTag),
+	}
+	if c.slotDuration == 0 {
+		c.slotDuration = defaultSlotDuration
+	}
+	if c.fallbackDelay == 0 {
+		c.fallbackDelay = defaultFallbackDelay
+	}
+	if time.Duration(len(c.relayers)-1)*c.fallbackDelay >= c.slotDuration {
+		return nil, errFallbackDelayTooLong
+	}
+	return c, nil
+}
+
+func (c *Coordinator) Start() error {
+	c.log.Info("Starting")
+
+	go c.contextCancelHandler()
+	c.listenerLoop()
+
+	return nil
+}
+
+func (c *Coordinator) Wait() {
+	<-c.doneCh
+}
+
+// Allowed returns true if this relayer is allowed to poke the given pair at
+// the given time. It returns false if another relayer announced its intent
+// to poke the pair during the last slot or if it is not yet this relayer's
+// turn.
+func (c *Coordinator) Allowed(assetPair string, t time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	self := c.signer.Address()
+	for addr, it := range c.intents[assetPair] {
+		if addr != self && t.Sub(it) < c.slotDuration {
+			return false
+		}
+	}
+
+	slot := t.UnixNano() / int64(c.slotDuration)
+	slotStart := time.Unix(0, slot*int64(c.slotDuration))
+	for n, addr := range c.order(assetPair, slot) {
+		if addr == self {
+			return t.Sub(slotStart) >= time.Duration(n)*c.fallbackDelay
+		}
+	}
+	return false
+}
+
+// Announce broadcasts the intent to poke the given pair to other relayers.
+func (c *Coordinator) Announce(assetPair string) error {
+	intent := &messages.RelayerIntent{
+		AssetPair: assetPair,
+		Timestamp: time.Now(),
+	}
+	if err := intent.Sign(c.signer); err != nil {
+		return err
+	}
+	return c.transport.Broadcast(messages.RelayerIntentMessageName, intent)
+}
+
+// order returns relayers in the order in which they are allowed to poke
+// the given pair in the given slot.
+func (c *Coordinator) order(assetPair string, slot int64) []ethereum.Address {
+	type relayer struct {
+		address ethereum.Address
+		hash    []byte
+	}
+	s := make([]byte, 8)
+	binary.BigEndian.PutUint64(s, uint64(slot))
+	var rs []relayer
+	for _, addr := range c.relayers {
+		var b []byte
+		b = append(b, addr.Bytes()...)
+		b = append(b, []byte(assetPair)...)
+		b = append(b, s...)
+		rs = append(rs, relayer{address: addr, hash: ethereum.SHA3Hash(b)})
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return bytes.Compare(rs[i].hash, rs[j].hash) < 0
+	})
+	var addrs []ethereum.Address
+	for _, r := range rs {
+		addrs = append(addrs, r.address)
+	}
+	return addrs
+}
+
+// collectIntent stores an intent received from another relayer.
+func (c *Coordinator) collectIntent(intent *messages.RelayerIntent) error {
+	from, err := intent.From(c.signer)
+	if err != nil {
+		return errInvalidIntentSignature
+	}
+	if !containsAddress(c.relayers, *from) {
+		return errUnknownRelayer
+	}
+	if time.Until(intent.Timestamp) > maxIntentClockSkew {
+		return errIntentFromFuture
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.intents[intent.AssetPair]; !ok {
+		c.intents[intent.AssetPair] = make(map[ethereum.Address]time.Time)
+	}
+	if intent.Timestamp.After(c.intents[intent.AssetPair][*from]) {
+		c.intents[intent.AssetPair][*from] = intent.Timestamp
+	}
+	return nil
+}
+
+// listenerLoop creates a asynchronous loop which collects intents from
+// other relayers.
+func (c *Coordinator) listenerLoop() {
+	go func() {
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case m := <-c.transport.Messages(messages.RelayerIntentMessageName):
+				if m.Error != nil {
+					c.log.
+						WithError(m.Error).
+						Warn("Unable to read relayer intents from the transport")
+					continue
+				}
+				intent, ok := m.Message.(*messages.RelayerIntent)
+				if !ok {
+					c.log.Error("Unexpected value returned from transport layer")
+					continue
+				}
+				if err := c.collectIntent(intent); err != nil {
+					c.log.
+						WithError(err).
+						WithField("assetPair", intent.AssetPair).
+						Warn("Received invalid relayer intent")
+					continue
+				}
+				c.log.
+					WithField("assetPair", intent.AssetPair).
+					Debug("Relayer intent received")
+			}
+		}
+	}()
+}
+
+func (c *Coordinator) contextCancelHandler() {
+	defer func() { close(c.doneCh) }()
+	defer c.log.Info("Stopped")
+	<-c.ctx.Done()
+}
+
+func containsAddress(addrs []ethereum.Address, addr ethereum.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
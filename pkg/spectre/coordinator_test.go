@@ -0,0 +1,169 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	ethereumMocks "github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+	"github.com/makerdao/oracle-suite/pkg/transport"
+	"github.com/makerdao/oracle-suite/pkg/transport/local"
+	"github.com/makerdao/oracle-suite/pkg/transport/messages"
+)
+
+var testRelayers = []ethereum.Address{
+	ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4"),
+	ethereum.HexToAddress("0xe3ced0f62f7eb2856d37bed128d2b195712d2644"),
+	ethereum.HexToAddress("0x9a5c6a1fb4ae3ea3ddfd8e6e0a2ceaafe8d4ad22"),
+}
+
+func newTestCoordinator(t *testing.T, self ethereum.Address) (*Coordinator, *ethereumMocks.Signer, *local.Local) {
+	sig := &ethereumMocks.Signer{}
+	sig.On("Address").Return(self)
+
+	tra := local.New(context.Background(), 10, map[string]transport.Message{
+		messages.RelayerIntentMessageName: (*messages.RelayerIntent)(nil),
+	})
+
+	c, err := NewCoordinator(context.Background(), CoordinatorConfig{
+		Signer:        sig,
+		Transport:     tra,
+		Relayers:      testRelayers,
+		SlotDuration:  time.Minute,
+		FallbackDelay: 10 * time.Second,
+		Logger:        null.New(),
+	})
+	require.NoError(t, err)
+	return c, sig, tra
+}
+
+func TestNewCoordinator_UnknownRelayer(t *testing.T) {
+	sig := &ethereumMocks.Signer{}
+	sig.On("Address").Return(ethereum.HexToAddress("0x01"))
+
+	_, err := NewCoordinator(context.Background(), CoordinatorConfig{
+		Signer:   sig,
+		Relayers: testRelayers,
+		Logger:   null.New(),
+	})
+	assert.ErrorIs(t, err, errUnknownRelayer)
+}
+
+func TestNewCoordinator_FallbackDelayTooLong(t *testing.T) {
+	sig := &ethereumMocks.Signer{}
+	sig.On("Address").Return(testRelayers[0])
+
+	// With the default 60s slots and 20s delay, the fourth relayer would
+	// never be allowed to poke.
+	_, err := NewCoordinator(context.Background(), CoordinatorConfig{
+		Signer:   sig,
+		Relayers: append(append([]ethereum.Address{}, testRelayers...), ethereum.HexToAddress("0x04")),
+		Logger:   null.New(),
+	})
+	assert.ErrorIs(t, err, errFallbackDelayTooLong)
+}
+
+func TestCoordinator_order(t *testing.T) {
+	c, _, _ := newTestCoordinator(t, testRelayers[0])
+
+	// The order must be deterministic and must contain all relayers:
+	order := c.order("AAABBB", 10)
+	assert.Equal(t, order, c.order("AAABBB", 10))
+	assert.ElementsMatch(t, testRelayers, order)
+
+	// The designated relayer should change between slots:
+	firsts := map[ethereum.Address]bool{}
+	for slot := int64(0); slot < 20; slot++ {
+		firsts[c.order("AAABBB", slot)[0]] = true
+	}
+	assert.Greater(t, len(firsts), 1)
+}
+
+func TestCoordinator_Allowed(t *testing.T) {
+	slotStart := time.Unix(600, 0)
+	order := (&Coordinator{relayers: testRelayers}).order("AAABBB", 10)
+
+	for n, self := range order {
+		c, _, _ := newTestCoordinator(t, self)
+		turn := slotStart.Add(time.Duration(n) * 10 * time.Second)
+
+		if n > 0 {
+			assert.False(t, c.Allowed("AAABBB", turn.Add(-time.Second)), "relayer %d", n)
+		}
+		assert.True(t, c.Allowed("AAABBB", turn), "relayer %d", n)
+	}
+}
+
+func TestCoordinator_Allowed_Intent(t *testing.T) {
+	slotStart := time.Unix(600, 0)
+	order := (&Coordinator{relayers: testRelayers}).order("AAABBB", 10)
+
+	// The designated relayer receives an intent from the last relayer:
+	c, sig, _ := newTestCoordinator(t, order[0])
+	sig.On("Recover", mock.Anything, mock.Anything).Return(&order[2], nil)
+	require.NoError(t, c.collectIntent(&messages.RelayerIntent{
+		AssetPair: "AAABBB",
+		Timestamp: slotStart.Add(-30 * time.Second),
+	}))
+
+	// Other relayer is about to poke the pair:
+	assert.False(t, c.Allowed("AAABBB", slotStart))
+	// The intent expires after the slot duration:
+	assert.True(t, c.Allowed("AAABBB", slotStart.Add(30*time.Second)))
+}
+
+func TestCoordinator_collectIntent_UnknownRelayer(t *testing.T) {
+	c, sig, _ := newTestCoordinator(t, testRelayers[0])
+	unknown := ethereum.HexToAddress("0x01")
+	sig.On("Recover", mock.Anything, mock.Anything).Return(&unknown, nil)
+
+	err := c.collectIntent(&messages.RelayerIntent{AssetPair: "AAABBB", Timestamp: time.Now()})
+	assert.ErrorIs(t, err, errUnknownRelayer)
+	assert.Empty(t, c.intents)
+}
+
+func TestCoordinator_collectIntent_Future(t *testing.T) {
+	c, sig, _ := newTestCoordinator(t, testRelayers[0])
+	sig.On("Recover", mock.Anything, mock.Anything).Return(&testRelayers[1], nil)
+
+	err := c.collectIntent(&messages.RelayerIntent{AssetPair: "AAABBB", Timestamp: time.Now().Add(time.Hour)})
+	assert.ErrorIs(t, err, errIntentFromFuture)
+	assert.Empty(t, c.intents)
+
+	// A small clock skew is allowed:
+	err = c.collectIntent(&messages.RelayerIntent{AssetPair: "AAABBB", Timestamp: time.Now().Add(time.Second)})
+	assert.NoError(t, err)
+	assert.Len(t, c.intents["AAABBB"], 1)
+}
+
+func TestCoordinator_Announce(t *testing.T) {
+	c, sig, tra := newTestCoordinator(t, testRelayers[0])
+	sig.On("Signature", mock.Anything).Return(ethereum.Signature{}, nil)
+
+	require.NoError(t, c.Announce("AAABBB"))
+
+	msg := <-tra.Messages(messages.RelayerIntentMessageName)
+	require.NoError(t, msg.Error)
+	assert.Equal(t, "AAABBB", msg.Message.(*messages.RelayerIntent).AssetPair)
+}
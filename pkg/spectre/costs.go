@@ -0,0 +1,256 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/log"
+)
+
+// CostDayFormat is the format of days used to index costs.
+const CostDayFormat = "2006-01-02"
+
+// Cost is the cost of poke transactions sent by Spectre.
+type Cost struct {
+	// Pokes is the number of mined poke transactions, including reverted
+	// ones.
+	Pokes int `json:"pokes"`
+	// GasUsed is the amount of gas used by poke transactions.
+	GasUsed uint64 `json:"gasUsed"`
+	// Fee is the amount paid for the gas, in wei.
+	Fee *big.Int `json:"fee"`
+}
+
+// Add returns the sum of both costs.
+func (c Cost) Add(o Cost) Cost {
+	fee := new(big.Int)
+	if c.Fee != nil {
+		fee.Add(fee, c.Fee)
+	}
+	if o.Fee != nil {
+		fee.Add(fee, o.Fee)
+	}
+	return Cost{Pokes: c.Pokes + o.Pokes, GasUsed: c.GasUsed + o.GasUsed, Fee: fee}
+}
+
+// CostDay returns the day, to which costs incurred at given time are
+// assigned. Days are in the UTC time zone.
+func CostDay(t time.Time) string {
+	return t.UTC().Format(CostDayFormat)
+}
+
+// CostStore persists the daily costs of pokes for every pair, so the daily
+// budgets are enforced after a restart and costs can be reported later.
+type CostStore interface {
+	// Load returns costs indexed by the pair name and the day.
+	Load() (map[string]map[string]Cost, error)
+	// Add adds the cost to the total cost of the pair on given day.
+	Add(name, day string, cost Cost) error
+}
+
+// FileCostStore is a CostStore which keeps costs in a JSON file.
+type FileCostStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCostStore returns a new FileCostStore instance. The file is
+// created on the first Add call.
+func NewFileCostStore(path string) *FileCostStore {
+	return &FileCostStore{path: path}
+}
+
+// Load implements the CostStore interface. It returns no costs if the file
+// does not exist.
+func (s *FileCostStore) Load() (map[string]map[string]Cost, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// Add implements the CostStore interface.
+func (s *FileCostStore) Add(name, day string, cost Cost) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	costs, err := s.load()
+	if err != nil {
+		return err
+	}
+	if costs[name] == nil {
+		costs[name] = make(map[string]Cost)
+	}
+	costs[name][day] = costs[name][day].Add(cost)
+	b, err := json.Marshal(costs)
+	if err != nil {
+		return err
+	}
+
+	// The file is replaced atomically, so costs are never left partially
+	// written:
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+func (s *FileCostStore) load() (map[string]map[string]Cost, error) {
+	costs := make(map[string]map[string]Cost)
+	b, err := ioutil.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return costs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, &costs); err != nil {
+		return nil, err
+	}
+	return costs, nil
+}
+
+// loadCosts restores today's costs from the cost store.
+func (s *Spectre) loadCosts() error {
+	if s.costStore == nil {
+		return nil
+	}
+	costs, err := s.costStore.Load()
+	if err != nil {
+		return fmt.Errorf("unable to load poke costs: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	day := CostDay(time.Now())
+	for name := range s.pairs {
+		for _, key := range []string{name, osmIntentKey(name)} {
+			if cost, ok := costs[key][day]; ok {
+				s.costs[key] = map[string]Cost{day: cost}
+				dailyFeesMetric.WithLabelValues(key).Set(weiToEther(cost.Fee))
+			}
+		}
+	}
+	return nil
+}
+
+// budgetExceeded returns true if today's costs of pokes for given pair
+// exceed its daily budget. The caller must hold the mutex.
+func (s *Spectre) budgetExceeded(name string) bool {
+	budget := s.pairs[name].DailyBudget
+	if budget == nil {
+		return false
+	}
+	fee := s.costs[name][CostDay(time.Now())].Fee
+	return fee != nil && fee.Cmp(budget) >= 0
+}
+
+// recordCost adds the cost of a mined poke transaction to today's costs of
+// given pair, or given OSM if the name is created by osmIntentKey. If the
+// transaction updated multiple pairs, the cost is split evenly between them.
+// The caller must hold the mutex.
+func (s *Spectre) recordCost(name string, hash ethereum.Hash, receipt *ethereum.Receipt) {
+	shares := s.pokeShares[hash]
+	if shares < 1 {
+		shares = 1
+	}
+	fee := new(big.Int)
+	if receipt.EffectiveGasPrice != nil {
+		fee.Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+		fee.Div(fee, big.NewInt(int64(shares)))
+	}
+	cost := Cost{Pokes: 1, GasUsed: receipt.GasUsed / uint64(shares), Fee: fee}
+
+	day := CostDay(time.Now())
+	if s.costs[name] == nil || s.costs[name][day].Fee == nil {
+		// Older days are not needed to enforce the budget:
+		s.costs[name] = map[string]Cost{day: {}}
+	}
+	daily := s.costs[name][day].Add(cost)
+	s.costs[name][day] = daily
+
+	pokeGasUsedMetric.WithLabelValues(name).Add(float64(cost.GasUsed))
+	pokeFeesMetric.WithLabelValues(name).Add(weiToEther(cost.Fee))
+	dailyFeesMetric.WithLabelValues(name).Set(weiToEther(daily.Fee))
+
+	fields := log.Fields{
+		"assetPair":     name,
+		"tx":            receipt.TxHash.String(),
+		"gasUsed":       cost.GasUsed,
+		"fee":           cost.Fee.String(),
+		"dailyFee":      daily.Fee.String(),
+		"dailyPokes":    daily.Pokes,
+		"sharedByPairs": shares,
+	}
+	if pair, ok := s.pairs[name]; ok && pair.DailyBudget != nil {
+		fields["dailyBudget"] = pair.DailyBudget.String()
+	}
+	s.log.WithFields(fields).Info("Poke cost recorded")
+
+	if s.costStore != nil {
+		if err := s.costStore.Add(name, day, cost); err != nil {
+			s.log.
+				WithFields(log.Fields{"assetPair": name}).
+				WithError(err).
+				Warn("Unable to save poke costs")
+		}
+	}
+}
+
+// releasePokeShare forgets the number of pairs updated by a poke
+// transaction once none of them waits for the transaction anymore. The
+// caller must hold the mutex.
+func (s *Spectre) releasePokeShare(hash ethereum.Hash) {
+	for _, pokes := range []map[string]ethereum.Hash{s.lastPokes, s.lastOSMPokes} {
+		for _, h := range pokes {
+			if h == hash {
+				return
+			}
+		}
+	}
+	delete(s.pokeShares, hash)
+}
+
+func weiToEther(wei *big.Int) float64 {
+	if wei == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18)).Float64()
+	return f
+}
@@ -0,0 +1,130 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+func TestFileCostStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spectre")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := NewFileCostStore(filepath.Join(dir, "costs.json"))
+
+	// Missing file:
+	costs, err := s.Load()
+	require.NoError(t, err)
+	assert.Empty(t, costs)
+
+	require.NoError(t, s.Add("AAABBB", "2021-01-01", Cost{Pokes: 1, GasUsed: 100, Fee: big.NewInt(1000)}))
+	require.NoError(t, s.Add("AAABBB", "2021-01-01", Cost{Pokes: 1, GasUsed: 50, Fee: big.NewInt(500)}))
+	require.NoError(t, s.Add("AAABBB", "2021-01-02", Cost{Pokes: 1, GasUsed: 10, Fee: big.NewInt(100)}))
+
+	costs, err = NewFileCostStore(filepath.Join(dir, "costs.json")).Load()
+	require.NoError(t, err)
+	require.Len(t, costs["AAABBB"], 2)
+	assert.Equal(t, 2, costs["AAABBB"]["2021-01-01"].Pokes)
+	assert.Equal(t, uint64(150), costs["AAABBB"]["2021-01-01"].GasUsed)
+	assert.Equal(t, big.NewInt(1500), costs["AAABBB"]["2021-01-01"].Fee)
+	assert.Equal(t, big.NewInt(100), costs["AAABBB"]["2021-01-02"].Fee)
+}
+
+func TestSpectre_Costs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spectre")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileCostStore(filepath.Join(dir, "costs.json"))
+	today := CostDay(time.Now())
+	require.NoError(t, store.Add("AAABBB", today, Cost{Pokes: 1, GasUsed: 100, Fee: big.NewInt(600)}))
+
+	s, err := NewSpectre(context.Background(), Config{
+		Pairs: []*Pair{
+			{AssetPair: "AAABBB", DailyBudget: big.NewInt(1000)},
+			{AssetPair: "CCCDDD"},
+		},
+		Costs:  store,
+		Logger: null.New(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.loadCosts())
+	assert.False(t, s.budgetExceeded("AAABBB"))
+
+	// A transaction which updated both pairs:
+	tx := ethereum.HexToHash("0x01")
+	s.pokeShares[tx] = 2
+	s.lastPokes["AAABBB"] = tx
+	s.lastPokes["CCCDDD"] = tx
+	receipt := &ethereum.Receipt{TxHash: tx, Success: true, GasUsed: 200, EffectiveGasPrice: big.NewInt(4)}
+	for _, name := range []string{"AAABBB", "CCCDDD"} {
+		s.recordCost(name, tx, receipt)
+		delete(s.lastPokes, name)
+		s.releasePokeShare(tx)
+	}
+	assert.Empty(t, s.pokeShares)
+
+	assert.Equal(t, Cost{Pokes: 2, GasUsed: 200, Fee: big.NewInt(1000)}, s.costs["AAABBB"][today])
+	assert.Equal(t, Cost{Pokes: 1, GasUsed: 100, Fee: big.NewInt(400)}, s.costs["CCCDDD"][today])
+	assert.True(t, s.budgetExceeded("AAABBB"))
+	assert.False(t, s.budgetExceeded("CCCDDD"))
+
+	costs, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), costs["AAABBB"][today].Fee)
+	assert.Equal(t, big.NewInt(400), costs["CCCDDD"][today].Fee)
+}
+
+func TestSpectre_Costs_OSM(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spectre")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileCostStore(filepath.Join(dir, "costs.json"))
+	today := CostDay(time.Now())
+	require.NoError(t, store.Add(osmIntentKey("AAABBB"), today, Cost{Pokes: 1, GasUsed: 100, Fee: big.NewInt(2000)}))
+
+	s, err := NewSpectre(context.Background(), Config{
+		Pairs:  []*Pair{{AssetPair: "AAABBB", DailyBudget: big.NewInt(1000)}},
+		Costs:  store,
+		Logger: null.New(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.loadCosts())
+	assert.Equal(t, big.NewInt(2000), s.costs[osmIntentKey("AAABBB")][today].Fee)
+
+	// Costs of OSM pokes do not count towards the budget of the pair:
+	tx := ethereum.HexToHash("0x01")
+	s.pokeShares[tx] = 1
+	receipt := &ethereum.Receipt{TxHash: tx, Success: true, GasUsed: 200, EffectiveGasPrice: big.NewInt(4)}
+	s.recordCost(osmIntentKey("AAABBB"), tx, receipt)
+	assert.Equal(t, big.NewInt(2800), s.costs[osmIntentKey("AAABBB")][today].Fee)
+	assert.Nil(t, s.costs["AAABBB"])
+	assert.False(t, s.budgetExceeded("AAABBB"))
+}
@@ -0,0 +1,184 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/makerdao/oracle-suite/internal/httpserver"
+	"github.com/makerdao/oracle-suite/internal/httpserver/middleware"
+	"github.com/makerdao/oracle-suite/pkg/log"
+	"github.com/makerdao/oracle-suite/pkg/spectre"
+)
+
+const LoggerTag = "SPECTRE_HTTP"
+
+// StatusProvider provides the status of relayed pairs. It is implemented
+// by the spectre.Spectre.
+type StatusProvider interface {
+	Status() []spectre.PairStatus
+}
+
+type Config struct {
+	// Spectre provides the status of pairs. The server does not start the
+	// Spectre instance, it must be started by the caller.
+	Spectre StatusProvider
+	// Address is a listen address of the HTTP server.
+	Address string
+	Logger  log.Logger
+}
+
+// Server serves the Spectre status over HTTP using the JSON format.
+//
+// The following endpoints are available:
+//   GET /status?pair=ETHUSD[,...]  - status of given pairs, or all pairs
+type Server struct {
+	ctx    context.Context
+	doneCh chan struct{}
+
+	srv     *httpserver.HTTPServer
+	spectre StatusProvider
+	log     log.Logger
+}
+
+// New returns a new Server instance.
+func New(ctx context.Context, cfg Config) (*Server, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	if cfg.Spectre == nil {
+		return nil, errors.New("spectre must not be nil")
+	}
+	s := &Server{
+		ctx:     ctx,
+		doneCh:  make(chan struct{}),
+		spectre: cfg.Spectre,
+		log:     cfg.Logger.WithField("tag", LoggerTag),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.statusHandler)
+
+	s.srv = httpserver.New(ctx, &http.Server{
+		Addr:    cfg.Address,
+		Handler: mux,
+	})
+	s.srv.Use(&middleware.Recover{
+		Recover: func(err interface{}) {
+			s.log.WithField("panic", fmt.Sprintf("%s", err)).Error("Server handler crashed")
+		},
+	})
+	s.srv.Use(&middleware.Logger{Log: s.log})
+	return s, nil
+}
+
+// Start starts the HTTP server.
+func (s *Server) Start() error {
+	s.log.Infof("Starting")
+	if err := s.srv.ListenAndServe(); err != nil {
+		return err
+	}
+	go s.contextCancelHandler()
+	return nil
+}
+
+// Wait waits until the server's context is cancelled.
+func (s *Server) Wait() {
+	<-s.doneCh
+}
+
+// Addr returns the server's network address. It returns nil if the server
+// is not started.
+func (s *Server) Addr() net.Addr {
+	return s.srv.Addr()
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	s.srv.ServeHTTP(rw, r)
+}
+
+func (s *Server) statusHandler(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		rw.Header().Set("Allow", http.MethodGet)
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	statuses := s.spectre.Status()
+	names := queryPairs(r)
+	if len(names) == 0 {
+		writeJSON(rw, http.StatusOK, statuses)
+		return
+	}
+	byName := make(map[string]spectre.PairStatus, len(statuses))
+	for _, st := range statuses {
+		byName[st.Name] = st
+	}
+	items := make([]spectre.PairStatus, 0, len(names))
+	for _, name := range names {
+		st, ok := byName[name]
+		if !ok {
+			writeJSON(rw, http.StatusNotFound, jsonError{Error: fmt.Sprintf("unknown pair: %s", name)})
+			return
+		}
+		items = append(items, st)
+	}
+	writeJSON(rw, http.StatusOK, items)
+}
+
+func (s *Server) contextCancelHandler() {
+	defer func() { close(s.doneCh) }()
+	defer s.log.Info("Stopped")
+	<-s.ctx.Done()
+
+	if err := s.srv.Wait(); err != nil {
+		s.log.WithError(err).Error("Unable to close HTTP server")
+	}
+}
+
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+// queryPairs returns pair names from the "pair" query parameter. Names may
+// be given as a comma separated list or as multiple parameters.
+func queryPairs(r *http.Request) []string {
+	var ss []string
+	for _, v := range r.URL.Query()["pair"] {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				ss = append(ss, p)
+			}
+		}
+	}
+	return ss
+}
+
+func writeJSON(rw http.ResponseWriter, code int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	_, _ = rw.Write(b)
+}
@@ -0,0 +1,113 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpapi
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+	"github.com/makerdao/oracle-suite/pkg/spectre"
+)
+
+type statusProvider []spectre.PairStatus
+
+func (p statusProvider) Status() []spectre.PairStatus {
+	return p
+}
+
+func newTestServer(t *testing.T, statuses ...spectre.PairStatus) *Server {
+	s, err := New(context.Background(), Config{
+		Spectre: statusProvider(statuses),
+		Address: "127.0.0.1:0",
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func serve(s *Server, method, url string) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	s.ServeHTTP(rw, httptest.NewRequest(method, url, nil))
+	return rw
+}
+
+func TestServer_Status(t *testing.T) {
+	ts := time.Unix(10, 0).UTC()
+	s := newTestServer(t,
+		spectre.PairStatus{
+			Name:       "AAABBB",
+			AssetPair:  "AAABBB",
+			Val:        big.NewInt(10),
+			Age:        ts,
+			Bar:        3,
+			Prices:     []spectre.FeederPrice{{Feeder: ethereum.HexToAddress("0x01"), Val: big.NewInt(11), Age: ts}},
+			Spread:     10,
+			Expiration: ts.Add(time.Minute),
+			LastPoke:   &spectre.PokeStatus{Tx: ethereum.HexToHash("0x02"), SentAt: ts, Status: "mined"},
+			UpdatedAt:  ts,
+		},
+		spectre.PairStatus{
+			Name:      "CCCDDD",
+			AssetPair: "CCCDDD",
+			Reason:    "err",
+			UpdatedAt: ts,
+		},
+	)
+
+	rw := serve(s, http.MethodGet, "/status?pair=AAABBB")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `[{
+		"name":"AAABBB",
+		"assetPair":"AAABBB",
+		"val":10,
+		"age":"1970-01-01T00:00:10Z",
+		"bar":3,
+		"prices":[{"feeder":"0x0000000000000000000000000000000000000001","val":11,"age":"1970-01-01T00:00:10Z"}],
+		"spread":10,
+		"expiration":"1970-01-01T00:01:10Z",
+		"lastPoke":{"tx":"0x0000000000000000000000000000000000000000000000000000000000000002","sentAt":"1970-01-01T00:00:10Z","status":"mined"},
+		"updatedAt":"1970-01-01T00:00:10Z"
+	}]`, rw.Body.String())
+
+	rw = serve(s, http.MethodGet, "/status")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"reason":"err"`)
+	assert.Contains(t, rw.Body.String(), `"name":"AAABBB"`)
+}
+
+func TestServer_StatusUnknownPair(t *testing.T) {
+	s := newTestServer(t)
+
+	rw := serve(s, http.MethodGet, "/status?pair=AAABBB")
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+	assert.JSONEq(t, `{"error":"unknown pair: AAABBB"}`, rw.Body.String())
+}
+
+func TestServer_StatusMethodNotAllowed(t *testing.T) {
+	s := newTestServer(t)
+
+	rw := serve(s, http.MethodPost, "/status")
+	assert.Equal(t, http.StatusMethodNotAllowed, rw.Code)
+}
@@ -0,0 +1,84 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	oracleAgeMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "spectre",
+		Name:      "oracle_age_seconds",
+		Help:      "Time elapsed since the last Oracle update.",
+	}, []string{"pair"})
+	oracleSpreadMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "spectre",
+		Name:      "oracle_spread_percent",
+		Help:      "Spread between the Oracle price and the median of prices from the datastore.",
+	}, []string{"pair"})
+	pokesMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "pokes_total",
+		Help:      "Number of poke transactions sent.",
+	}, []string{"pair"})
+	pokeFailuresMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "poke_failures_total",
+		Help:      "Number of poke transactions which could not be sent.",
+	}, []string{"pair"})
+	pokeRevertsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "poke_reverts_total",
+		Help:      "Number of poke transactions which were mined but reverted.",
+	}, []string{"pair"})
+	osmPokesMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "osm_pokes_total",
+		Help:      "Number of OSM poke transactions sent.",
+	}, []string{"pair"})
+	osmPokeFailuresMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "osm_poke_failures_total",
+		Help:      "Number of OSM poke transactions which could not be sent.",
+	}, []string{"pair"})
+	osmPokeRevertsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "osm_poke_reverts_total",
+		Help:      "Number of OSM poke transactions which were mined but reverted.",
+	}, []string{"pair"})
+	pokeGasUsedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "poke_gas_used_total",
+		Help:      "Amount of gas used by mined poke transactions.",
+	}, []string{"pair"})
+	pokeFeesMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "poke_fees_eth_total",
+		Help:      "Amount of ETH paid for mined poke transactions.",
+	}, []string{"pair"})
+	dailyFeesMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "spectre",
+		Name:      "daily_fees_eth",
+		Help:      "Amount of ETH paid for poke transactions during the current day (UTC).",
+	}, []string{"pair"})
+	relayErrorsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spectre",
+		Name:      "relay_errors_total",
+		Help:      "Number of failed attempts to update an Oracle.",
+	}, []string{"pair"})
+)
@@ -18,14 +18,26 @@ package spectre
 import (
 	"math"
 	"math/big"
-	"math/rand"
 	"sort"
 	"time"
 
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
 	"github.com/makerdao/oracle-suite/pkg/oracle"
 	"github.com/makerdao/oracle-suite/pkg/transport/messages"
 )
 
+// PriceSelection describes how prices used to update an Oracle are selected
+// if there are more prices than required to achieve a quorum.
+type PriceSelection int
+
+const (
+	// SelectFreshest selects the most recent prices.
+	SelectFreshest PriceSelection = iota
+	// SelectClosestToMedian selects prices closest to the median of all
+	// available prices.
+	SelectClosestToMedian
+)
+
 // prices contains a list of messages.Price's for a single asset pair.
 type prices struct {
 	msgs []*messages.Price
@@ -57,23 +69,60 @@ func (p *prices) oraclePrices() []*oracle.Price {
 	return prices
 }
 
-// truncate removes random msgs until the number of remaining prices is equal
-// to n. If the number of prices is less or equal to n, it does nothing.
+// selectPrices removes prices until the number of remaining prices is equal
+// to n. Remaining prices are chosen using the given strategy. If the number
+// of prices is less or equal to n, it does nothing.
 //
 // This method is used to reduce number of arguments in transaction which will
 // reduce transaction costs.
-func (p *prices) truncate(n int64) {
+func (p *prices) selectPrices(s PriceSelection, n int64) {
 	if int64(len(p.msgs)) <= n {
 		return
 	}
 
-	rand.Shuffle(len(p.msgs), func(i, j int) {
-		p.msgs[i], p.msgs[j] = p.msgs[j], p.msgs[i]
-	})
+	switch s {
+	case SelectClosestToMedian:
+		median := p.median()
+		dist := func(i int) *big.Int {
+			return new(big.Int).Abs(new(big.Int).Sub(p.msgs[i].Price.Val, median))
+		}
+		sort.SliceStable(p.msgs, func(i, j int) bool {
+			if c := dist(i).Cmp(dist(j)); c != 0 {
+				return c < 0
+			}
+			return p.msgs[i].Price.Age.After(p.msgs[j].Price.Age)
+		})
+	default:
+		sort.SliceStable(p.msgs, func(i, j int) bool {
+			return p.msgs[i].Price.Age.After(p.msgs[j].Price.Age)
+		})
+	}
 
 	p.msgs = p.msgs[0:n]
 }
 
+// onePerFeeder removes all prices except the most recent one from every
+// feeder. Prices with an invalid signature are removed as well.
+func (p *prices) onePerFeeder(signer ethereum.Signer) {
+	var prices []*messages.Price
+	feeders := map[ethereum.Address]int{}
+	for _, price := range p.msgs {
+		from, err := price.Price.From(signer)
+		if err != nil {
+			continue
+		}
+		if i, ok := feeders[*from]; ok {
+			if price.Price.Age.After(prices[i].Price.Age) {
+				prices[i] = price
+			}
+			continue
+		}
+		feeders[*from] = len(prices)
+		prices = append(prices, price)
+	}
+	p.msgs = prices
+}
+
 // median calculates the median price for all messages in the list.
 func (p *prices) median() *big.Int {
 	count := len(p.msgs)
@@ -113,6 +162,17 @@ func (p *prices) spread(price *big.Int) float64 {
 	return math.Abs(xf)
 }
 
+// clearNotNewerThan deletes messages which are not newer than given time.
+func (p *prices) clearNotNewerThan(t time.Time) {
+	var prices []*messages.Price
+	for _, price := range p.msgs {
+		if price.Price.Age.After(t) {
+			prices = append(prices, price)
+		}
+	}
+	p.msgs = prices
+}
+
 // clearOlderThan deletes messages which are older than given time.
 func (p *prices) clearOlderThan(t time.Time) {
 	var prices []*messages.Price
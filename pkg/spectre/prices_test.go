@@ -16,6 +16,7 @@
 package spectre
 
 import (
+	"errors"
 	"math"
 	"math/big"
 	"strconv"
@@ -23,8 +24,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/makerdao/oracle-suite/pkg/datastore/memory/testutil"
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/mocks"
+	"github.com/makerdao/oracle-suite/pkg/oracle"
 	"github.com/makerdao/oracle-suite/pkg/transport/messages"
 )
 
@@ -69,7 +74,7 @@ func TestPrices_oraclePrices(t *testing.T) {
 	assert.Contains(t, ps.oraclePrices(), testutil.PriceAAABBB4.Price)
 }
 
-func TestPrices_truncate(t *testing.T) {
+func TestPrices_selectPrices(t *testing.T) {
 	msgs := []*messages.Price{
 		testutil.PriceAAABBB1,
 		testutil.PriceAAABBB2,
@@ -77,17 +82,39 @@ func TestPrices_truncate(t *testing.T) {
 		testutil.PriceAAABBB4,
 	}
 
-	ps1 := newPrices(msgs)
-	ps1.truncate(5)
+	ps1 := newPrices(append([]*messages.Price{}, msgs...))
+	ps1.selectPrices(SelectFreshest, 5)
 	assert.Len(t, ps1.messages(), 4)
 
-	ps2 := newPrices(msgs)
-	ps2.truncate(4)
+	ps2 := newPrices(append([]*messages.Price{}, msgs...))
+	ps2.selectPrices(SelectFreshest, 4)
 	assert.Len(t, ps2.messages(), 4)
 
-	ps3 := newPrices(msgs)
-	ps3.truncate(3)
-	assert.Len(t, ps3.messages(), 3)
+	ps3 := newPrices(append([]*messages.Price{}, msgs...))
+	ps3.selectPrices(SelectFreshest, 2)
+	assert.Equal(t, []*messages.Price{testutil.PriceAAABBB4, testutil.PriceAAABBB3}, ps3.messages())
+
+	ps4 := newPrices(append([]*messages.Price{}, msgs[0:3]...))
+	ps4.selectPrices(SelectClosestToMedian, 1)
+	assert.Equal(t, []*messages.Price{testutil.PriceAAABBB2}, ps4.messages())
+}
+
+func TestPrices_onePerFeeder(t *testing.T) {
+	sig := &mocks.Signer{}
+	sig.On("Recover", testutil.PriceAAABBB1.Price.Signature(), mock.Anything).Return(&testutil.Address1, nil)
+	sig.On("Recover", testutil.PriceAAABBB2.Price.Signature(), mock.Anything).Return(&testutil.Address1, nil)
+	sig.On("Recover", testutil.PriceAAABBB3.Price.Signature(), mock.Anything).Return(&testutil.Address2, nil)
+	sig.On("Recover", testutil.PriceAAABBB4.Price.Signature(), mock.Anything).Return((*ethereum.Address)(nil), errors.New("invalid signature"))
+
+	ps := newPrices([]*messages.Price{
+		testutil.PriceAAABBB1,
+		testutil.PriceAAABBB2,
+		testutil.PriceAAABBB3,
+		testutil.PriceAAABBB4,
+	})
+	ps.onePerFeeder(sig)
+
+	assert.Equal(t, []*messages.Price{testutil.PriceAAABBB2, testutil.PriceAAABBB3}, ps.messages())
 }
 
 func TestPrices_median_Even(t *testing.T) {
@@ -153,6 +180,19 @@ func TestPrices_spread(t *testing.T) {
 	}
 }
 
+func TestPrices_clearNotNewerThan(t *testing.T) {
+	ps := newPrices([]*messages.Price{
+		testutil.PriceAAABBB1,
+		testutil.PriceAAABBB2,
+		testutil.PriceAAABBB3,
+		testutil.PriceAAABBB4,
+	})
+
+	ps.clearNotNewerThan(time.Unix(300, 0))
+
+	assert.Equal(t, []*oracle.Price{testutil.PriceAAABBB4.Price}, ps.oraclePrices())
+}
+
 func TestPrices_clearOlderThan(t *testing.T) {
 	ps := newPrices([]*messages.Price{
 		testutil.PriceAAABBB1,
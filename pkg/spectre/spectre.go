@@ -19,11 +19,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/makerdao/oracle-suite/pkg/datastore"
 	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/ethereum/txmanager"
 	"github.com/makerdao/oracle-suite/pkg/log"
 	"github.com/makerdao/oracle-suite/pkg/oracle"
 )
@@ -57,32 +61,90 @@ func (e errNoPrices) Error() string {
 	return fmt.Sprintf("there is no prices in the datastore for %s pair", e.AssetPair)
 }
 
+type errPendingTransaction struct {
+	AssetPair string
+	Hash      ethereum.Hash
+}
+
+func (e errPendingTransaction) Error() string {
+	return fmt.Sprintf(
+		"unable to update the Oracle for %s pair, the previous transaction %s is still pending",
+		e.AssetPair,
+		e.Hash.String(),
+	)
+}
+
+type errBudgetExceeded struct {
+	AssetPair string
+	Budget    *big.Int
+}
+
+func (e errBudgetExceeded) Error() string {
+	return fmt.Sprintf(
+		"unable to update the Oracle for %s pair, the daily budget of %s wei is exceeded, "+
+			"the Oracle will be updated when its price expires",
+		e.AssetPair,
+		e.Budget.String(),
+	)
+}
+
+type errNotRelayerTurn struct {
+	AssetPair string
+}
+
+func (e errNotRelayerTurn) Error() string {
+	return fmt.Sprintf(
+		"unable to update the Oracle for %s pair, it is another relayer's turn",
+		e.AssetPair,
+	)
+}
+
 type Spectre struct {
 	ctx    context.Context
 	mu     sync.Mutex
 	doneCh chan struct{}
 
-	signer    ethereum.Signer
-	datastore datastore.Datastore
-	interval  time.Duration
-	log       log.Logger
-	pairs     map[string]*Pair
+	signer       ethereum.Signer
+	datastore    datastore.Datastore
+	coordinator  *Coordinator
+	interval     time.Duration
+	log          log.Logger
+	pairs        map[string]*Pair
+	lastPokes    map[string]ethereum.Hash
+	lastOSMPokes map[string]ethereum.Hash
+
+	statusMu sync.RWMutex
+	status   map[string]*PairStatus
+
+	costStore  CostStore
+	costs      map[string]map[string]Cost
+	pokeShares map[ethereum.Hash]int
 }
 
 type Config struct {
 	Signer ethereum.Signer
 	// Datastore provides prices for Spectre.
 	Datastore datastore.Datastore
+	// Coordinator is an optional coordinator used to avoid sending duplicate
+	// pokes by multiple Spectre instances.
+	Coordinator *Coordinator
 	// Interval describes how often we should try to update Oracles.
 	Interval time.Duration
 	// Pairs is the list supported pairs by Spectre with their configuration.
 	Pairs []*Pair
+	// Costs is an optional store used to persist the daily costs of pokes.
+	// Without it, costs are counted only since the start of Spectre.
+	Costs CostStore
 	// Logger is a current logger interface used by the Spectre. The Logger is
 	// required to monitor asynchronous processes.
 	Logger log.Logger
 }
 
 type Pair struct {
+	// Name is the unique name of the pair used in logs and metrics. If
+	// empty, the AssetPair is used. Different names allow to relay the same
+	// asset pair to multiple Oracle contracts, e.g. on different chains.
+	Name string
 	// AssetPair is the name of asset pair, e.g. ETHUSD.
 	AssetPair string
 	// OracleSpread is the minimum spread between the Oracle price and new price
@@ -94,9 +156,33 @@ type Pair struct {
 	// PriceExpiration is the maximum amount of time before price received
 	// from the feeder will be considered as expired.
 	PriceExpiration time.Duration
+	// PriceSelection is the strategy used to select prices if there are
+	// more prices than required to achieve a quorum.
+	PriceSelection PriceSelection
 	// Median is the instance of the oracle.Median which is the interface for
 	// the Oracle contract.
 	Median oracle.Median
+	// OSM is an optional instance of the oracle.OSM which reads prices from
+	// the Median contract. If set, the OSM is poked every time its hop has
+	// passed.
+	OSM oracle.OSM
+	// TxManager is an optional transaction manager used to send poke
+	// transactions. If set, the result of every poke is reported and
+	// a pair is not poked until the previous transaction is mined.
+	TxManager *txmanager.TxManager
+	// Multicall is an optional multicall contract. Pokes for all pairs
+	// with the same Multicall are sent in a single transaction. Pokes that
+	// would fail are excluded from the transaction and reported separately.
+	// If any of the remaining pokes fails once mined, the transaction is
+	// reverted for all of them.
+	Multicall oracle.Multicall
+	// DailyBudget is the maximum amount, in wei, spent on pokes for the pair
+	// during a single day (UTC). Once exceeded, the Oracle is updated only
+	// when its price expires, regardless of the spread. If nil, the budget
+	// is unlimited. Costs are known only for transactions sent through
+	// the TxManager. Costs of OSM pokes are recorded separately, under
+	// the "<pair>/OSM" name, and they are not limited by the budget.
+	DailyBudget *big.Int
 }
 
 func NewSpectre(ctx context.Context, cfg Config) (*Spectre, error) {
@@ -104,16 +190,26 @@ func NewSpectre(ctx context.Context, cfg Config) (*Spectre, error) {
 		return nil, errors.New("context must not be nil")
 	}
 	r := &Spectre{
-		ctx:       ctx,
-		doneCh:    make(chan struct{}),
-		signer:    cfg.Signer,
-		datastore: cfg.Datastore,
-		interval:  cfg.Interval,
-		pairs:     make(map[string]*Pair),
-		log:       cfg.Logger.WithField("tag", LoggerTag),
+		ctx:          ctx,
+		doneCh:       make(chan struct{}),
+		signer:       cfg.Signer,
+		datastore:    cfg.Datastore,
+		coordinator:  cfg.Coordinator,
+		interval:     cfg.Interval,
+		pairs:        make(map[string]*Pair),
+		lastPokes:    make(map[string]ethereum.Hash),
+		lastOSMPokes: make(map[string]ethereum.Hash),
+		status:       make(map[string]*PairStatus),
+		costStore:    cfg.Costs,
+		costs:        make(map[string]map[string]Cost),
+		pokeShares:   make(map[ethereum.Hash]int),
+		log:          cfg.Logger.WithField("tag", LoggerTag),
 	}
 	for _, p := range cfg.Pairs {
-		r.pairs[p.AssetPair] = p
+		if p.Name == "" {
+			p.Name = p.AssetPair
+		}
+		r.pairs[p.Name] = p
 	}
 	return r, nil
 }
@@ -121,6 +217,10 @@ func NewSpectre(ctx context.Context, cfg Config) (*Spectre, error) {
 func (s *Spectre) Start() error {
 	s.log.Info("Starting")
 
+	if err := s.loadCosts(); err != nil {
+		return err
+	}
+
 	go s.contextCancelHandler()
 	s.relayerLoop()
 
@@ -133,18 +233,110 @@ func (s *Spectre) Wait() {
 
 // relay tries to update an Oracle contract for given pair. It'll return
 // transaction hash or nil if there is no need to update Oracle.
-func (s *Spectre) relay(assetPair string) (*ethereum.Hash, error) {
+func (s *Spectre) relay(name string) (*ethereum.Hash, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	pair, ok := s.pairs[assetPair]
+	prices, err := s.pokePrices(name)
+	if err != nil || prices == nil {
+		return nil, err
+	}
+
+	// Send *actual* transaction to the Ethereum network:
+	s.announce(name)
+	tx, err := s.pairs[name].Median.Poke(s.ctx, prices, true)
+	if err != nil {
+		pokeFailuresMetric.WithLabelValues(name).Inc()
+	} else {
+		pokesMetric.WithLabelValues(name).Inc()
+		s.lastPokes[name] = *tx
+		s.pokeShares[*tx] = 1
+	}
+	return tx, err
+}
+
+// relayBatch tries to update Oracle contracts for given pairs using a single
+// transaction sent through the multicall contract. It returns transaction
+// hashes and errors for every pair. The hash is nil if there is no need to
+// update the Oracle.
+func (s *Spectre) relayBatch(
+	multicall oracle.Multicall,
+	names []string,
+) (map[string]*ethereum.Hash, map[string]error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		txs   = make(map[string]*ethereum.Hash)
+		errs  = make(map[string]error)
+		pairs []string
+		calls []ethereum.Call
+	)
+
+	for _, name := range names {
+		prices, err := s.pokePrices(name)
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		if prices == nil {
+			continue
+		}
+		call, err := s.pairs[name].Median.PokeCall(prices)
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		pairs = append(pairs, name)
+		calls = append(calls, call)
+	}
+	if len(calls) == 0 {
+		return txs, errs
+	}
+
+	// Send *actual* transaction to the Ethereum network:
+	for _, name := range pairs {
+		s.announce(name)
+	}
+	tx, callErrs, err := multicall.Send(s.ctx, calls)
+	for n, name := range pairs {
+		switch {
+		case err != nil:
+			errs[name] = err
+		case callErrs[n] != nil:
+			errs[name] = callErrs[n]
+		default:
+			txs[name] = tx
+		}
+		if errs[name] != nil {
+			pokeFailuresMetric.WithLabelValues(name).Inc()
+		} else {
+			pokesMetric.WithLabelValues(name).Inc()
+			s.lastPokes[name] = *tx
+			s.pokeShares[*tx]++
+		}
+	}
+	return txs, errs
+}
+
+// pokePrices returns prices which should be used to update an Oracle
+// contract for given pair or nil if there is no need to update Oracle.
+// The caller must hold the mutex.
+func (s *Spectre) pokePrices(name string) ([]*oracle.Price, error) {
+	pair, ok := s.pairs[name]
 	if !ok {
-		return nil, errUnknownAsset{AssetPair: assetPair}
+		return nil, errUnknownAsset{AssetPair: name}
 	}
 
-	prices := newPrices(s.datastore.Prices().AssetPair(assetPair))
-	if prices == nil || prices.len() == 0 {
-		return nil, errNoPrices{AssetPair: assetPair}
+	pokePending := s.checkLastPoke(name)
+
+	prices := newPrices(s.datastore.Prices().AssetPair(pair.AssetPair))
+	s.updateStatus(name, func(st *PairStatus) {
+		st.Prices = feederPrices(prices.messages(), s.signer)
+	})
+	if prices.len() == 0 {
+		return nil, errNoPrices{AssetPair: name}
 	}
 
 	oracleQuorum, err := pair.Median.Bar(s.ctx)
@@ -160,21 +352,36 @@ func (s *Spectre) relay(assetPair string) (*ethereum.Hash, error) {
 		return nil, err
 	}
 
-	// Clear expired prices:
+	// Clear expired prices. The Oracle accepts only prices newer than its
+	// current age:
 	prices.clearOlderThan(time.Now().Add(-1 * pair.PriceExpiration))
-	prices.clearOlderThan(oracleTime)
+	prices.clearNotNewerThan(oracleTime)
+
+	// The Oracle accepts only one price from each feeder:
+	prices.onePerFeeder(s.signer)
 
 	// Use only a minimum prices required to achieve a quorum:
-	prices.truncate(oracleQuorum)
+	prices.selectPrices(pair.PriceSelection, oracleQuorum)
 
 	spread := prices.spread(oraclePrice)
 	isExpired := oracleTime.Add(pair.OracleExpiration).Before(time.Now())
 	isStale := spread >= pair.OracleSpread
 
+	oracleAgeMetric.WithLabelValues(name).Set(time.Since(oracleTime).Seconds())
+	oracleSpreadMetric.WithLabelValues(name).Set(spread)
+
+	s.updateStatus(name, func(st *PairStatus) {
+		st.Val = oraclePrice
+		st.Age = oracleTime
+		st.Bar = oracleQuorum
+		st.Spread = spread
+		st.Expiration = oracleTime.Add(pair.OracleExpiration)
+	})
+
 	// Print logs:
 	s.log.
 		WithFields(log.Fields{
-			"assetPair":        assetPair,
+			"assetPair":        name,
 			"bar":              oracleQuorum,
 			"age":              oracleTime.String(),
 			"val":              oraclePrice.String(),
@@ -193,20 +400,156 @@ func (s *Spectre) relay(assetPair string) (*ethereum.Hash, error) {
 	}
 
 	if isExpired || isStale {
+		// Update the Oracle only on expiration if the daily budget is
+		// exceeded:
+		if !isExpired && s.budgetExceeded(name) {
+			return nil, errBudgetExceeded{AssetPair: name, Budget: pair.DailyBudget}
+		}
+
+		// Wait until the previous poke transaction is mined:
+		if pokePending {
+			return nil, errPendingTransaction{AssetPair: name, Hash: s.lastPokes[name]}
+		}
+
 		// Check if there are enough prices to achieve a quorum:
 		if int64(prices.len()) != oracleQuorum {
-			return nil, errNotEnoughPricesForQuorum{AssetPair: assetPair}
+			return nil, errNotEnoughPricesForQuorum{AssetPair: name}
+		}
+
+		// Check if it is this relayer's turn to update the Oracle:
+		if s.coordinator != nil && !s.coordinator.Allowed(name, time.Now()) {
+			return nil, errNotRelayerTurn{AssetPair: name}
 		}
 
-		// Send *actual* transaction to the Ethereum network:
-		tx, err := pair.Median.Poke(s.ctx, prices.oraclePrices(), true)
-		return tx, err
+		return prices.oraclePrices(), nil
 	}
 
 	// There is no need to update Oracle:
 	return nil, nil
 }
 
+// announce informs other relayers about the intent to update an Oracle
+// contract for given pair.
+func (s *Spectre) announce(name string) {
+	if s.coordinator == nil {
+		return
+	}
+	if err := s.coordinator.Announce(name); err != nil {
+		s.log.
+			WithFields(log.Fields{"assetPair": name}).
+			WithError(err).
+			Warn("Unable to announce the intent to update Oracle")
+	}
+}
+
+// relayOSM tries to poke an OSM contract for given pair. It'll return
+// transaction hash or nil if the OSM is not configured or there is no need
+// to poke it.
+func (s *Spectre) relayOSM(name string) (*ethereum.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pair, ok := s.pairs[name]
+	if !ok {
+		return nil, errUnknownAsset{AssetPair: name}
+	}
+	if pair.OSM == nil {
+		return nil, nil
+	}
+
+	pokePending := s.checkLastOSMPoke(name)
+
+	pass, err := pair.OSM.Pass(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !pass {
+		return nil, nil
+	}
+
+	// Wait until the previous poke transaction is mined:
+	if pokePending {
+		return nil, errPendingTransaction{AssetPair: name, Hash: s.lastOSMPokes[name]}
+	}
+
+	// Check if it is this relayer's turn to poke the OSM:
+	if s.coordinator != nil && !s.coordinator.Allowed(osmIntentKey(name), time.Now()) {
+		return nil, errNotRelayerTurn{AssetPair: name}
+	}
+
+	// Send *actual* transaction to the Ethereum network:
+	s.announce(osmIntentKey(name))
+	tx, err := pair.OSM.Poke(s.ctx, true)
+	if err != nil {
+		osmPokeFailuresMetric.WithLabelValues(name).Inc()
+	} else {
+		osmPokesMetric.WithLabelValues(name).Inc()
+		s.lastOSMPokes[name] = *tx
+		s.pokeShares[*tx] = 1
+	}
+	return tx, err
+}
+
+// checkLastPoke reports the result of the last poke transaction for the
+// given pair once it is known. It returns true if the transaction is still
+// pending.
+func (s *Spectre) checkLastPoke(name string) bool {
+	return s.checkPokeTx(s.lastPokes, name, name, "Oracle", pokeRevertsMetric, pokeFailuresMetric)
+}
+
+// checkLastOSMPoke works like checkLastPoke but for OSM poke transactions.
+// Costs of OSM pokes are recorded separately from the Oracle pokes, so they
+// do not count towards the daily budget of the pair.
+func (s *Spectre) checkLastOSMPoke(name string) bool {
+	return s.checkPokeTx(s.lastOSMPokes, name, osmIntentKey(name), "OSM", osmPokeRevertsMetric, osmPokeFailuresMetric)
+}
+
+func (s *Spectre) checkPokeTx(
+	pokes map[string]ethereum.Hash,
+	name string,
+	costKey string,
+	contract string,
+	revertsMetric *prometheus.CounterVec,
+	failuresMetric *prometheus.CounterVec,
+) bool {
+
+	hash, ok := pokes[name]
+	if !ok || s.pairs[name].TxManager == nil {
+		return false
+	}
+	tx, ok := s.pairs[name].TxManager.Transaction(hash)
+	if !ok {
+		delete(pokes, name)
+		s.releasePokeShare(hash)
+		return false
+	}
+	fields := log.Fields{"assetPair": name, "tx": tx.Hashes[len(tx.Hashes)-1].String()}
+	switch tx.Status {
+	case txmanager.StatusPending, txmanager.StatusStuck:
+		return true
+	case txmanager.StatusMined:
+		s.log.
+			WithFields(fields).
+			Info(contract + " update confirmed")
+	case txmanager.StatusReverted:
+		revertsMetric.WithLabelValues(name).Inc()
+		s.log.
+			WithFields(fields).
+			Error(contract + " update transaction reverted")
+	case txmanager.StatusDropped:
+		failuresMetric.WithLabelValues(name).Inc()
+		s.log.
+			WithFields(fields).
+			Warn(contract + " update transaction dropped")
+	}
+	if tx.Receipt != nil {
+		s.recordCost(costKey, hash, tx.Receipt)
+	}
+	delete(pokes, name)
+	s.releasePokeShare(hash)
+	return false
+}
+
 // relayerLoop creates a asynchronous loop which tries to send an update
 // to an Oracle contract at a specified interval.
 func (s *Spectre) relayerLoop() {
@@ -222,34 +565,96 @@ func (s *Spectre) relayerLoop() {
 				ticker.Stop()
 				return
 			case <-ticker.C:
-				for assetPair := range s.pairs {
-					tx, err := s.relay(assetPair)
-
-					// Print log in case of an error:
-					if err != nil {
-						s.log.
-							WithFields(log.Fields{"assetPair": assetPair}).
-							WithError(err).
-							Warn("Unable to update Oracle")
-					}
-					// Print log if there was no need to update prices:
-					if err == nil && tx == nil {
-						s.log.
-							WithFields(log.Fields{"assetPair": assetPair}).
-							Info("Oracle price is still valid")
-					}
-					// Print log if Oracle update transaction was sent:
-					if tx != nil {
-						s.log.
-							WithFields(log.Fields{"assetPair": assetPair, "tx": tx.String()}).
-							Info("Oracle updated")
-					}
-				}
+				s.relayAll()
 			}
 		}
 	}()
 }
 
+// relayAll tries to update Oracle contracts for all pairs. Updates for pairs
+// with the same multicall contract are sent in a single transaction. After
+// that, OSM contracts are poked if needed.
+func (s *Spectre) relayAll() {
+	batches := make(map[oracle.Multicall][]string)
+	for name, pair := range s.pairs {
+		if pair.Multicall != nil {
+			batches[pair.Multicall] = append(batches[pair.Multicall], name)
+			continue
+		}
+		tx, err := s.relay(name)
+		s.logRelay(name, tx, err)
+		s.recordRelay(name, tx, err)
+	}
+	for multicall, names := range batches {
+		txs, errs := s.relayBatch(multicall, names)
+		for _, name := range names {
+			s.logRelay(name, txs[name], errs[name])
+			s.recordRelay(name, txs[name], errs[name])
+		}
+	}
+	for name, pair := range s.pairs {
+		if pair.OSM == nil {
+			continue
+		}
+		tx, err := s.relayOSM(name)
+		s.logOSMRelay(name, tx, err)
+	}
+}
+
+// logRelay prints the result of an Oracle update for given pair.
+func (s *Spectre) logRelay(name string, tx *ethereum.Hash, err error) {
+	// Print log if the update was skipped because of the budget:
+	if errors.As(err, &errBudgetExceeded{}) {
+		s.log.
+			WithFields(log.Fields{"assetPair": name}).
+			WithError(err).
+			Info("Oracle update skipped")
+		return
+	}
+	// Print log in case of an error:
+	if err != nil {
+		relayErrorsMetric.WithLabelValues(name).Inc()
+		s.log.
+			WithFields(log.Fields{"assetPair": name}).
+			WithError(err).
+			Warn("Unable to update Oracle")
+	}
+	// Print log if there was no need to update prices:
+	if err == nil && tx == nil {
+		s.log.
+			WithFields(log.Fields{"assetPair": name}).
+			Info("Oracle price is still valid")
+	}
+	// Print log if Oracle update transaction was sent:
+	if tx != nil {
+		s.log.
+			WithFields(log.Fields{"assetPair": name, "tx": tx.String()}).
+			Info("Oracle updated")
+	}
+}
+
+// logOSMRelay prints the result of an OSM poke for given pair.
+func (s *Spectre) logOSMRelay(name string, tx *ethereum.Hash, err error) {
+	if err != nil {
+		relayErrorsMetric.WithLabelValues(name).Inc()
+		s.log.
+			WithFields(log.Fields{"assetPair": name}).
+			WithError(err).
+			Warn("Unable to poke OSM")
+	}
+	if tx != nil {
+		s.log.
+			WithFields(log.Fields{"assetPair": name, "tx": tx.String()}).
+			Info("OSM poked")
+	}
+}
+
+// osmIntentKey returns the key used to coordinate OSM pokes between
+// relayers and to record their costs.
+func osmIntentKey(name string) string {
+	return name + "/OSM"
+}
+
 func (s *Spectre) contextCancelHandler() {
 	defer func() { close(s.doneCh) }()
 	defer s.log.Info("Stopped")
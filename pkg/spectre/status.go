@@ -0,0 +1,155 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/transport/messages"
+)
+
+// PairStatus describes the state of an Oracle contract and the result of
+// the last attempt to update it.
+type PairStatus struct {
+	// Name is the unique name of the pair.
+	Name string `json:"name"`
+	// AssetPair is the name of asset pair.
+	AssetPair string `json:"assetPair"`
+	// Val is the current price in the Oracle contract.
+	Val *big.Int `json:"val"`
+	// Age is the time of the last Oracle update.
+	Age time.Time `json:"age"`
+	// Bar is the number of prices required to update the Oracle.
+	Bar int64 `json:"bar"`
+	// Prices is the list of candidate prices from the datastore, the most
+	// recent price for every feeder.
+	Prices []FeederPrice `json:"prices"`
+	// Spread is the spread between the Oracle price and the median of
+	// selected prices, in percent.
+	Spread float64 `json:"spread"`
+	// Expiration is the time after which the Oracle price is considered
+	// expired and will be updated regardless of the spread.
+	Expiration time.Time `json:"expiration"`
+	// LastPoke is the last poke transaction sent for the pair, if any.
+	LastPoke *PokeStatus `json:"lastPoke,omitempty"`
+	// Reason explains why the poke was not sent during the last attempt.
+	// It is empty if the poke was sent.
+	Reason string `json:"reason,omitempty"`
+	// UpdatedAt is the time of the last attempt to update the Oracle.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// FeederPrice is a price sent by a feeder.
+type FeederPrice struct {
+	Feeder ethereum.Address `json:"feeder"`
+	Val    *big.Int         `json:"val"`
+	Age    time.Time        `json:"age"`
+}
+
+// PokeStatus describes a poke transaction.
+type PokeStatus struct {
+	Tx     ethereum.Hash `json:"tx"`
+	SentAt time.Time     `json:"sentAt"`
+	// Status is the status of the transaction reported by the transaction
+	// manager or "unknown" if the transaction is not tracked.
+	Status string `json:"status"`
+}
+
+const reasonPriceValid = "the Oracle price is still valid"
+const pokeStatusUnknown = "unknown"
+
+// Status returns the status of all pairs sorted by their names.
+func (s *Spectre) Status() []PairStatus {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+
+	st := make([]PairStatus, 0, len(s.pairs))
+	for name, pair := range s.pairs {
+		ps := PairStatus{Name: name, AssetPair: pair.AssetPair}
+		if cur, ok := s.status[name]; ok {
+			ps = *cur
+		}
+		if ps.LastPoke != nil {
+			lp := *ps.LastPoke
+			lp.Status = pokeStatusUnknown
+			if pair.TxManager != nil {
+				if tx, ok := pair.TxManager.Transaction(lp.Tx); ok {
+					lp.Status = tx.Status.String()
+				}
+			}
+			ps.LastPoke = &lp
+		}
+		st = append(st, ps)
+	}
+	sort.Slice(st, func(i, j int) bool {
+		return st[i].Name < st[j].Name
+	})
+	return st
+}
+
+// updateStatus modifies the status of given pair.
+func (s *Spectre) updateStatus(name string, fn func(st *PairStatus)) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	st, ok := s.status[name]
+	if !ok {
+		st = &PairStatus{Name: name}
+		if pair, ok := s.pairs[name]; ok {
+			st.AssetPair = pair.AssetPair
+		}
+		s.status[name] = st
+	}
+	fn(st)
+}
+
+// recordRelay records the result of an attempt to update an Oracle.
+func (s *Spectre) recordRelay(name string, tx *ethereum.Hash, err error) {
+	s.updateStatus(name, func(st *PairStatus) {
+		st.UpdatedAt = time.Now()
+		switch {
+		case err != nil:
+			st.Reason = err.Error()
+		case tx == nil:
+			st.Reason = reasonPriceValid
+		default:
+			st.Reason = ""
+			st.LastPoke = &PokeStatus{Tx: *tx, SentAt: st.UpdatedAt}
+		}
+	})
+}
+
+// feederPrices returns the most recent price from every feeder.
+func feederPrices(msgs []*messages.Price, signer ethereum.Signer) []FeederPrice {
+	p := newPrices(append([]*messages.Price{}, msgs...))
+	p.onePerFeeder(signer)
+
+	var fps []FeederPrice
+	for _, msg := range p.messages() {
+		from, err := msg.Price.From(signer)
+		if err != nil {
+			continue
+		}
+		fps = append(fps, FeederPrice{Feeder: *from, Val: msg.Price.Val, Age: msg.Price.Age})
+	}
+	sort.Slice(fps, func(i, j int) bool {
+		return fps[i].Feeder.String() < fps[j].Feeder.String()
+	})
+	return fps
+}
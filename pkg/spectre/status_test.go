@@ -0,0 +1,58 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+)
+
+func TestSpectre_Status(t *testing.T) {
+	s, err := NewSpectre(context.Background(), Config{
+		Pairs: []*Pair{
+			{AssetPair: "BBBCCC"},
+			{Name: "AAABBB/L2", AssetPair: "AAABBB"},
+		},
+		Logger: null.New(),
+	})
+	require.NoError(t, err)
+
+	tx := ethereum.HexToHash("0x01")
+	s.recordRelay("AAABBB/L2", &tx, nil)
+	s.recordRelay("AAABBB/L2", nil, errors.New("err"))
+	s.recordRelay("BBBCCC", nil, nil)
+
+	st := s.Status()
+	require.Len(t, st, 2)
+
+	assert.Equal(t, "AAABBB/L2", st[0].Name)
+	assert.Equal(t, "AAABBB", st[0].AssetPair)
+	assert.Equal(t, "err", st[0].Reason)
+	require.NotNil(t, st[0].LastPoke)
+	assert.Equal(t, tx, st[0].LastPoke.Tx)
+	assert.Equal(t, pokeStatusUnknown, st[0].LastPoke.Status)
+
+	assert.Equal(t, "BBBCCC", st[1].Name)
+	assert.Equal(t, reasonPriceValid, st[1].Reason)
+	assert.Nil(t, st[1].LastPoke)
+}
@@ -0,0 +1,160 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starkex
+
+import (
+	"math/big"
+)
+
+// Parameters of the STARK-friendly elliptic curve:
+//   y^2 = x^3 + alpha*x + beta (mod p)
+var (
+	curveP     = hexToInt("0800000000000011000000000000000000000000000000000000000000000001")
+	curveAlpha = big.NewInt(1)
+	curveBeta  = hexToInt("06f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89")
+	curveN     = hexToInt("0800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f")
+	curveG     = &point{
+		x: hexToInt("01ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"),
+		y: hexToInt("005668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"),
+	}
+)
+
+// maxValue is the upper bound (exclusive) for hashed messages and the
+// signature components.
+var maxValue = new(big.Int).Lsh(big.NewInt(1), 251)
+
+// point is a point on the curve in affine coordinates. The nil pointer
+// represents the point at infinity.
+type point struct {
+	x, y *big.Int
+}
+
+func (p *point) equal(q *point) bool {
+	if p == nil || q == nil {
+		return p == q
+	}
+	return p.x.Cmp(q.x) == 0 && p.y.Cmp(q.y) == 0
+}
+
+func (p *point) neg() *point {
+	if p == nil {
+		return nil
+	}
+	return &point{x: new(big.Int).Set(p.x), y: new(big.Int).Sub(curveP, p.y)}
+}
+
+// onCurve verifies if the point lies on the curve.
+func (p *point) onCurve() bool {
+	if p == nil {
+		return true
+	}
+	return new(big.Int).Exp(p.y, big.NewInt(2), curveP).Cmp(curveY2(p.x)) == 0
+}
+
+// add returns p+q.
+func (p *point) add(q *point) *point {
+	switch {
+	case p == nil:
+		return q
+	case q == nil:
+		return p
+	case p.x.Cmp(q.x) == 0:
+		if p.y.Cmp(q.y) == 0 && p.y.Sign() != 0 {
+			return p.double()
+		}
+		return nil
+	}
+	// m = (qy - py) / (qx - px)
+	m := new(big.Int).Sub(q.y, p.y)
+	d := new(big.Int).Sub(q.x, p.x)
+	d.Mod(d, curveP)
+	m.Mul(m, d.ModInverse(d, curveP))
+	m.Mod(m, curveP)
+	return p.fromSlope(q, m)
+}
+
+// double returns 2p.
+func (p *point) double() *point {
+	if p == nil || p.y.Sign() == 0 {
+		return nil
+	}
+	// m = (3*px^2 + alpha) / (2*py)
+	m := new(big.Int).Mul(p.x, p.x)
+	m.Mul(m, big.NewInt(3))
+	m.Add(m, curveAlpha)
+	d := new(big.Int).Lsh(p.y, 1)
+	d.Mod(d, curveP)
+	m.Mul(m, d.ModInverse(d, curveP))
+	m.Mod(m, curveP)
+	return p.fromSlope(p, m)
+}
+
+// fromSlope returns the sum of p and q using a precomputed slope m.
+func (p *point) fromSlope(q *point, m *big.Int) *point {
+	// x = m^2 - px - qx
+	x := new(big.Int).Mul(m, m)
+	x.Sub(x, p.x)
+	x.Sub(x, q.x)
+	x.Mod(x, curveP)
+	// y = m * (px - x) - py
+	y := new(big.Int).Sub(p.x, x)
+	y.Mul(y, m)
+	y.Sub(y, p.y)
+	y.Mod(y, curveP)
+	return &point{x: x, y: y}
+}
+
+// mul returns k*p using the double-and-add method.
+func (p *point) mul(k *big.Int) *point {
+	var r *point
+	a := p
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			r = a.add(r)
+		}
+		a = a.double()
+	}
+	return r
+}
+
+// curveY2 returns x^3 + alpha*x + beta (mod p).
+func curveY2(x *big.Int) *big.Int {
+	y2 := new(big.Int).Exp(x, big.NewInt(3), curveP)
+	y2.Add(y2, new(big.Int).Mul(curveAlpha, x))
+	y2.Add(y2, curveBeta)
+	return y2.Mod(y2, curveP)
+}
+
+// pointFromX returns a point with the given x coordinate. The sign of the
+// y coordinate is not defined. It returns nil if there is no such point.
+func pointFromX(x *big.Int) *point {
+	if x.Sign() < 0 || x.Cmp(curveP) >= 0 {
+		return nil
+	}
+	y := new(big.Int).ModSqrt(curveY2(x), curveP)
+	if y == nil {
+		return nil
+	}
+	return &point{x: new(big.Int).Set(x), y: y}
+}
+
+func hexToInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("starkex: invalid hex number: " + s)
+	}
+	return n
+}
@@ -0,0 +1,151 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starkex
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+var ErrInvalidPrivateKey = errors.New("invalid stark private key")
+var ErrMessageTooLarge = errors.New("message hash must be lower than 2^251")
+
+// PrivateKey is a private key on the STARK-friendly curve used by StarkEx.
+type PrivateKey struct {
+	d   *big.Int
+	pub *big.Int
+}
+
+// NewPrivateKey returns a new PrivateKey for the given secret. The secret
+// must be in the range [1, N) where N is the order of the curve.
+func NewPrivateKey(d *big.Int) (*PrivateKey, error) {
+	if d.Sign() <= 0 || d.Cmp(curveN) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	return &PrivateKey{
+		d:   new(big.Int).Set(d),
+		pub: curveG.mul(d).x,
+	}, nil
+}
+
+// DerivePrivateKey derives a stark key from a seed, for example from
+// a private key of an Ethereum account, using the StarkWare's key grinding
+// algorithm.
+func DerivePrivateKey(seed []byte) (*PrivateKey, error) {
+	return NewPrivateKey(grindKey(seed))
+}
+
+// PublicKey returns the x coordinate of the public key.
+func (k *PrivateKey) PublicKey() *big.Int {
+	return new(big.Int).Set(k.pub)
+}
+
+// Bytes returns the private key as a 32 bytes long big-endian number.
+func (k *PrivateKey) Bytes() []byte {
+	return k.d.FillBytes(make([]byte, 32))
+}
+
+// Sign signs the message hash using the StarkEx ECDSA algorithm.
+func (k *PrivateKey) Sign(hash *big.Int) (r, s *big.Int, err error) {
+	if hash.Sign() < 0 || hash.Cmp(maxValue) >= 0 {
+		return nil, nil, ErrMessageTooLarge
+	}
+	for {
+		n, err := rand.Int(rand.Reader, new(big.Int).Sub(curveN, big.NewInt(1)))
+		if err != nil {
+			return nil, nil, err
+		}
+		n.Add(n, big.NewInt(1))
+
+		// r = (k*G).x
+		r = curveG.mul(n).x
+		if r.Sign() == 0 || r.Cmp(maxValue) >= 0 {
+			continue
+		}
+
+		// w = k / (hash + r*d) (mod N)
+		w := new(big.Int).Mul(r, k.d)
+		w.Add(w, hash)
+		w.Mod(w, curveN)
+		if w.Sign() == 0 {
+			continue
+		}
+		w.ModInverse(w, curveN)
+		w.Mul(w, n)
+		w.Mod(w, curveN)
+		if w.Sign() == 0 || w.Cmp(maxValue) >= 0 {
+			continue
+		}
+
+		// s = w^-1 (mod N)
+		return r, new(big.Int).ModInverse(w, curveN), nil
+	}
+}
+
+// Verify verifies the StarkEx ECDSA signature of the message hash. The
+// public key is the x coordinate of the public key point.
+func Verify(pub, hash, r, s *big.Int) bool {
+	if hash.Sign() < 0 || hash.Cmp(maxValue) >= 0 {
+		return false
+	}
+	if r.Sign() <= 0 || r.Cmp(maxValue) >= 0 {
+		return false
+	}
+	if s.Sign() <= 0 || s.Cmp(curveN) >= 0 {
+		return false
+	}
+	w := new(big.Int).ModInverse(s, curveN)
+	if w == nil || w.Cmp(maxValue) >= 0 {
+		return false
+	}
+	q := pointFromX(pub)
+	if q == nil {
+		return false
+	}
+
+	// Only the x coordinate of the public key is known, so both possible
+	// points have to be checked:
+	zG := curveG.mul(new(big.Int).Mod(new(big.Int).Mul(hash, w), curveN))
+	rQ := q.mul(new(big.Int).Mod(new(big.Int).Mul(r, w), curveN))
+	for _, p := range []*point{zG.add(rQ), zG.add(rQ.neg())} {
+		if p != nil && p.x.Cmp(r) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// grindKey derives a key lower than the curve order from the seed without
+// introducing a modulo bias.
+func grindKey(seed []byte) *big.Int {
+	limit := new(big.Int).Lsh(big.NewInt(1), 256)
+	limit.Sub(limit, new(big.Int).Mod(limit, curveN))
+
+	seed = new(big.Int).SetBytes(seed).Bytes()
+	for i := int64(0); ; i++ {
+		index := big.NewInt(i).Bytes()
+		if len(index) == 0 {
+			index = []byte{0}
+		}
+		h := sha256.Sum256(append(append([]byte{}, seed...), index...))
+		key := new(big.Int).SetBytes(h[:])
+		if key.Cmp(limit) < 0 {
+			return key.Mod(key, curveN)
+		}
+	}
+}
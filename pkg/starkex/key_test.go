@@ -0,0 +1,89 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starkex
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateKey_PublicKey(t *testing.T) {
+	k, err := NewPrivateKey(hexToInt("03c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc"))
+	require.NoError(t, err)
+	assert.Equal(t, "77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43", k.PublicKey().Text(16))
+}
+
+func TestVerify(t *testing.T) {
+	assert.True(t, Verify(
+		hexToInt("077a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43"),
+		hexToInt("0397e76d1667c4454bfb83514e120583af836f8e32a516765497823eabe16a3f"),
+		hexToInt("0173fd03d8b008ee7432977ac27d1e9d1a1f6c98b1a2f05fa84a21c84c44e882"),
+		hexToInt("04b6d75385aed025aa222f28a0adc6d58db78ff17e51c3f59e259b131cd5a1cc"),
+	))
+}
+
+func TestPrivateKey_Sign(t *testing.T) {
+	k, err := DerivePrivateKey([]byte("seed"))
+	require.NoError(t, err)
+
+	hash := big.NewInt(12345)
+	r, s, err := k.Sign(hash)
+	require.NoError(t, err)
+
+	assert.True(t, Verify(k.PublicKey(), hash, r, s))
+	assert.False(t, Verify(k.PublicKey(), big.NewInt(12346), r, s))
+	assert.False(t, Verify(k.PublicKey(), hash, s, r))
+}
+
+func TestNewPrivateKey_Invalid(t *testing.T) {
+	_, err := NewPrivateKey(big.NewInt(0))
+	assert.Equal(t, ErrInvalidPrivateKey, err)
+	_, err = NewPrivateKey(curveN)
+	assert.Equal(t, ErrInvalidPrivateKey, err)
+}
+
+func TestGrindKey(t *testing.T) {
+	k := grindKey([]byte("seed"))
+	assert.True(t, k.Sign() > 0)
+	assert.True(t, k.Cmp(curveN) < 0)
+	assert.Equal(t, k, grindKey([]byte("seed")))
+}
+
+func TestGrindKey_Reference(t *testing.T) {
+	// Values generated using the grind_key function from the StarkWare
+	// reference implementation, which hashes the seed as an integer:
+	tests := []struct {
+		seed string
+		want string
+	}{
+		{
+			seed: "86f3e7293141f20a8baff320e8ee4accb9d4a4bf2b4d295e8cee784db46e0519",
+			want: "5c8c8683596c732541a59e03007b2d30dbbbb873556fe65b5fb63c16688f941",
+		},
+		{
+			seed: "0011111111111111111111111111111111111111111111111111111111111111",
+			want: "3cb3d83ea54f849e0cc6f72ec87a1981e022fdd6954d3548c63a0b9e3b08771",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.seed, func(t *testing.T) {
+			assert.Equal(t, tt.want, grindKey(hexToInt(tt.seed).FillBytes(make([]byte, 32))).Text(16))
+		})
+	}
+}
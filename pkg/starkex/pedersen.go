@@ -0,0 +1,73 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starkex
+
+import (
+	"errors"
+	"math/big"
+)
+
+var ErrPedersenInputTooLarge = errors.New("pedersen hash input must be lower than the curve prime")
+
+// Constant points used by the Pedersen hash function, as defined by
+// StarkWare.
+var (
+	pedersenShiftPoint = &point{
+		x: hexToInt("049ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804"),
+		y: hexToInt("03ca0cfe4b3bc6ddf346d49d06ea0ed34e621062c0e056c1d0405d266e10268a"),
+	}
+	pedersenPoints = [4]*point{
+		{
+			x: hexToInt("0234287dcbaffe7f969c748655fca9e58fa8120b6d56eb0c1080d17957ebe47b"),
+			y: hexToInt("03b056f100f96fb21e889527d41f4e39940135dd7a6c94cc6ed0268ee89e5615"),
+		},
+		{
+			x: hexToInt("04fa56f376c83db33f9dab2656558f3399099ec1de5e3018b7a6932dba8aa378"),
+			y: hexToInt("03fa0984c931c9e38113e0c0e47e4401562761f92a7a23b45168f4e80ff5b54d"),
+		},
+		{
+			x: hexToInt("04ba4cc166be8dec764910f75b45f74b40c690c74709e90f3aa372f0bd2d6997"),
+			y: hexToInt("0040301cf5c1751f4b971e46c4ede85fcac5c59a5ce5ae7c48151f27b24b219c"),
+		},
+		{
+			x: hexToInt("054302dcb0e6cc1c6e44cca8f61a63bb2ca65048d53fb325d36ff12c49a58202"),
+			y: hexToInt("01b77b3e37d13504b348046268d8ae25ce98ad783c25561a879dcc77e99c2426"),
+		},
+	}
+)
+
+// pedersenLowBits is the number of low bits of every input which are
+// multiplied by the first of a pair of constant points.
+const pedersenLowBits = 248
+
+// PedersenHash calculates the StarkWare Pedersen hash of two field
+// elements.
+func PedersenHash(a, b *big.Int) (*big.Int, error) {
+	r := pedersenShiftPoint
+	for i, x := range []*big.Int{a, b} {
+		if x.Sign() < 0 || x.Cmp(curveP) >= 0 {
+			return nil, ErrPedersenInputTooLarge
+		}
+		low := new(big.Int).SetBits(nil)
+		for j := 0; j < pedersenLowBits; j++ {
+			low.SetBit(low, j, x.Bit(j))
+		}
+		high := new(big.Int).Rsh(x, pedersenLowBits)
+		r = r.add(pedersenPoints[i*2].mul(low))
+		r = r.add(pedersenPoints[i*2+1].mul(high))
+	}
+	return new(big.Int).Set(r.x), nil
+}
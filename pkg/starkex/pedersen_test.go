@@ -0,0 +1,41 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starkex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurvePoints(t *testing.T) {
+	assert.True(t, curveG.onCurve())
+	assert.True(t, pedersenShiftPoint.onCurve())
+	for _, p := range pedersenPoints {
+		assert.True(t, p.onCurve())
+	}
+	assert.Nil(t, curveG.mul(curveN))
+}
+
+func TestPedersenHash(t *testing.T) {
+	h, err := PedersenHash(
+		hexToInt("03d937c035c878245caf64531a5756109c53068da139362728feb561405371cb"),
+		hexToInt("0208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662", h.Text(16))
+}
@@ -0,0 +1,223 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fanout
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/makerdao/oracle-suite/pkg/log"
+	"github.com/makerdao/oracle-suite/pkg/transport"
+)
+
+const LoggerTag = "FANOUT"
+
+// dedupSize is the number of recently received messages remembered to
+// detect duplicates.
+const dedupSize = 10000
+
+// FanOut is an implementation of the transport.Transport interface which
+// wraps multiple transports. Messages are broadcast to all of them and
+// messages received from any of them are merged into a single channel.
+//
+// An error in one of transports does not affect other ones. The Broadcast
+// method returns an error only if a message could not be sent using any
+// of the transports.
+type FanOut struct {
+	mu        sync.Mutex
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	doneCh    chan struct{}
+
+	transports []transport.Transport
+	msgs       map[string]chan transport.ReceivedMessage
+	dedup      *dedup
+	log        log.Logger
+}
+
+// New returns a new instance of the FanOut structure. The ctx must be
+// the context used by all given transports, and ctxCancel must cancel it.
+// It is used to stop already started transports if one of them fails
+// to start.
+func New(
+	ctx context.Context,
+	ctxCancel context.CancelFunc,
+	logger log.Logger,
+	transports ...transport.Transport,
+) (*FanOut, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	if ctxCancel == nil {
+		return nil, errors.New("context cancel function must not be nil")
+	}
+	if len(transports) == 0 {
+		return nil, errors.New("at least one transport is required")
+	}
+	return &FanOut{
+		ctx:        ctx,
+		ctxCancel:  ctxCancel,
+		doneCh:     make(chan struct{}),
+		transports: transports,
+		msgs:       make(map[string]chan transport.ReceivedMessage),
+		dedup:      newDedup(dedupSize),
+		log:        logger.WithField("tag", LoggerTag),
+	}, nil
+}
+
+// Start implements the transport.Transport interface. It starts all
+// underlying transports. If any of them fails to start, the context is
+// canceled and the method waits for already started transports to stop.
+func (f *FanOut) Start() error {
+	for i, t := range f.transports {
+		if err := t.Start(); err != nil {
+			f.ctxCancel()
+			for _, s := range f.transports[:i] {
+				s.Wait()
+			}
+			return err
+		}
+	}
+	go f.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the transport.Transport interface.
+func (f *FanOut) Wait() {
+	<-f.doneCh
+}
+
+// Broadcast implements the transport.Transport interface.
+func (f *FanOut) Broadcast(topic string, message transport.Message) error {
+	var err error
+	var sent bool
+	for i, t := range f.transports {
+		if tErr := t.Broadcast(topic, message); tErr != nil {
+			err = multierror.Append(err, tErr)
+			f.log.
+				WithError(tErr).
+				WithFields(log.Fields{"topic": topic, "transport": i}).
+				Warn("Unable to broadcast message")
+			continue
+		}
+		sent = true
+	}
+	if sent {
+		return nil
+	}
+	return err
+}
+
+// Messages implements the transport.Transport interface. Messages from
+// all transports are merged into a single channel. If the same message is
+// received from multiple transports, only the first one is delivered.
+// The channel of every underlying transport is obtained only once, so
+// transports must return the same channel on every call.
+func (f *FanOut) Messages(topic string) chan transport.ReceivedMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if ch, ok := f.msgs[topic]; ok {
+		return ch
+	}
+	var chs []chan transport.ReceivedMessage
+	for _, t := range f.transports {
+		if tCh := t.Messages(topic); tCh != nil {
+			chs = append(chs, tCh)
+		}
+	}
+	if len(chs) == 0 {
+		return nil
+	}
+	ch := make(chan transport.ReceivedMessage)
+	for _, tCh := range chs {
+		go f.forwardMessages(topic, tCh, ch)
+	}
+	f.msgs[topic] = ch
+	return ch
+}
+
+// forwardMessages reads messages from a single transport and forwards
+// them to the merged channel.
+func (f *FanOut) forwardMessages(topic string, tCh, ch chan transport.ReceivedMessage) {
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case msg, ok := <-tCh:
+			if !ok {
+				return
+			}
+			if msg.Error == nil && f.isDuplicate(topic, msg.Message) {
+				continue
+			}
+			select {
+			case <-f.ctx.Done():
+				return
+			case ch <- msg:
+			}
+		}
+	}
+}
+
+func (f *FanOut) isDuplicate(topic string, msg transport.Message) bool {
+	b, err := msg.Marshall()
+	if err != nil {
+		return false
+	}
+	return !f.dedup.add(sha256.Sum256(append([]byte(topic+"\x00"), b...)))
+}
+
+func (f *FanOut) contextCancelHandler() {
+	defer func() { close(f.doneCh) }()
+	<-f.ctx.Done()
+	for _, t := range f.transports {
+		t.Wait()
+	}
+}
+
+// dedup remembers a limited number of recently seen message hashes.
+type dedup struct {
+	mu     sync.Mutex
+	size   int
+	hashes map[[32]byte]struct{}
+	queue  [][32]byte
+}
+
+func newDedup(size int) *dedup {
+	return &dedup{size: size, hashes: make(map[[32]byte]struct{}, size)}
+}
+
+// add adds a hash to the set. It returns false if the hash already exists.
+func (d *dedup) add(h [32]byte) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.hashes[h]; ok {
+		return false
+	}
+	d.hashes[h] = struct{}{}
+	d.queue = append(d.queue, h)
+	if len(d.queue) > d.size {
+		delete(d.hashes, d.queue[0])
+		d.queue = d.queue[1:]
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fanout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/makerdao/oracle-suite/pkg/log/null"
+	"github.com/makerdao/oracle-suite/pkg/transport"
+	"github.com/makerdao/oracle-suite/pkg/transport/local"
+)
+
+type testMsg struct {
+	Val string
+}
+
+func (t *testMsg) Marshall() ([]byte, error) {
+	return []byte(t.Val), nil
+}
+
+func (t *testMsg) Unmarshall(b []byte) error {
+	t.Val = string(b)
+	return nil
+}
+
+type failingTransport struct {
+	transport.Transport
+}
+
+func (failingTransport) Broadcast(string, transport.Message) error {
+	return errors.New("failed")
+}
+
+type failingStartTransport struct {
+	transport.Transport
+}
+
+func (failingStartTransport) Start() error {
+	return errors.New("failed")
+}
+
+func newTestFanOut(ctx context.Context, ctxCancel context.CancelFunc, t *testing.T, ts ...transport.Transport) *FanOut {
+	f, err := New(ctx, ctxCancel, null.New(), ts...)
+	require.NoError(t, err)
+	require.NoError(t, f.Start())
+	return f
+}
+
+func newTestLocal(ctx context.Context) *local.Local {
+	return local.New(ctx, 10, map[string]transport.Message{"foo": (*testMsg)(nil)})
+}
+
+func TestFanOut_Messages(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	t1 := newTestLocal(ctx)
+	t2 := newTestLocal(ctx)
+	f := newTestFanOut(ctx, ctxCancel, t, t1, t2)
+
+	// The same message received from both transports must be delivered
+	// only once:
+	require.NoError(t, t1.Broadcast("foo", &testMsg{Val: "a"}))
+	require.NoError(t, t2.Broadcast("foo", &testMsg{Val: "a"}))
+	require.NoError(t, t2.Broadcast("foo", &testMsg{Val: "b"}))
+
+	var vals []string
+	timeout := time.After(time.Second)
+	for len(vals) < 2 {
+		select {
+		case msg := <-f.Messages("foo"):
+			require.NoError(t, msg.Error)
+			vals = append(vals, msg.Message.(*testMsg).Val)
+		case <-timeout:
+			t.Fatal("timeout")
+		}
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, vals)
+
+	select {
+	case msg := <-f.Messages("foo"):
+		t.Fatalf("unexpected message: %v", msg.Message)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.Nil(t, f.Messages("bar"))
+}
+
+func TestFanOut_Broadcast(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	t1 := newTestLocal(ctx)
+	f := newTestFanOut(ctx, ctxCancel, t, failingTransport{Transport: newTestLocal(ctx)}, t1)
+
+	// An error in one transport must not affect other ones:
+	require.NoError(t, f.Broadcast("foo", &testMsg{Val: "a"}))
+	msg := <-t1.Messages("foo")
+	require.NoError(t, msg.Error)
+	assert.Equal(t, "a", msg.Message.(*testMsg).Val)
+
+	// If all transports fail, an error is returned:
+	f = newTestFanOut(ctx, ctxCancel, t, failingTransport{Transport: newTestLocal(ctx)})
+	assert.Error(t, f.Broadcast("foo", &testMsg{Val: "a"}))
+}
+
+func TestFanOut_StartFailure(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	t1 := newTestLocal(ctx)
+	f, err := New(ctx, ctxCancel, null.New(), t1, failingStartTransport{Transport: newTestLocal(ctx)})
+	require.NoError(t, err)
+
+	// Already started transports must be stopped:
+	assert.Error(t, f.Start())
+	assert.Error(t, ctx.Err())
+	assert.Error(t, t1.Broadcast("foo", &testMsg{Val: "a"}))
+}
+
+func TestDedup(t *testing.T) {
+	d := newDedup(2)
+	assert.True(t, d.add([32]byte{1}))
+	assert.False(t, d.add([32]byte{1}))
+	assert.True(t, d.add([32]byte{2}))
+	assert.True(t, d.add([32]byte{3}))
+	// The oldest hash is forgotten:
+	assert.True(t, d.add([32]byte{1}))
+}
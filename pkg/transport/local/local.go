@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"sync"
 
 	"github.com/makerdao/oracle-suite/pkg/transport"
 )
@@ -28,12 +29,18 @@ var ErrNotSubscribed = errors.New("topic is not subscribed")
 // Local is a simple implementation of the transport.Transport interface
 // using local channels.
 type Local struct {
+	mu     sync.Mutex
+	wg     sync.WaitGroup
 	ctx    context.Context
 	doneCh chan struct{} // doneCh is used to unblock the Wait method.
-	subs   map[string]subscription
+	closed bool
+	subs   map[string]*subscription
 }
 
 type subscription struct {
+	// started is true if messages are already being delivered to the status
+	// channel.
+	started bool
 	// typ is the structure type to which the message must be unmarshalled.
 	typ reflect.Type
 	// msgs is a channel used to broadcast raw message data.
@@ -51,10 +58,10 @@ func New(ctx context.Context, b int, s map[string]transport.Message) *Local {
 	l := &Local{
 		ctx:    ctx,
 		doneCh: make(chan struct{}),
-		subs:   make(map[string]subscription),
+		subs:   make(map[string]*subscription),
 	}
 	for topic, typ := range s {
-		l.subs[topic] = subscription{
+		l.subs[topic] = &subscription{
 			typ:    reflect.TypeOf(typ).Elem(),
 			msgs:   make(chan []byte, b),
 			status: make(chan transport.ReceivedMessage),
@@ -76,7 +83,11 @@ func (l *Local) Wait() {
 
 // Broadcast implements the transport.Transport interface.
 func (l *Local) Broadcast(topic string, message transport.Message) error {
-	if sub, ok := l.subs[topic]; ok {
+	l.mu.Lock()
+	sub, ok := l.subs[topic]
+	closed := l.closed
+	l.mu.Unlock()
+	if ok && !closed {
 		b, err := message.Marshall()
 		if err != nil {
 			return err
@@ -87,24 +98,44 @@ func (l *Local) Broadcast(topic string, message transport.Message) error {
 	return ErrNotSubscribed
 }
 
-// Messages implements the transport.Transport interface.
+// Messages implements the transport.Transport interface. Every call
+// returns the same channel for the given topic.
 func (l *Local) Messages(topic string) chan transport.ReceivedMessage {
-	if sub, ok := l.subs[topic]; ok {
-		go func() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sub, ok := l.subs[topic]
+	if !ok || l.closed {
+		return nil
+	}
+	if !sub.started {
+		sub.started = true
+		l.wg.Add(1)
+		go l.deliverMessages(sub)
+	}
+	return sub.status
+}
+
+// deliverMessages unmarshalls broadcast messages and sends them to the
+// status channel until the context is canceled.
+func (l *Local) deliverMessages(sub *subscription) {
+	defer l.wg.Done()
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case msg := <-sub.msgs:
+			message := reflect.New(sub.typ).Interface().(transport.Message)
 			select {
 			case <-l.ctx.Done():
 				return
-			case msg := <-sub.msgs:
-				message := reflect.New(sub.typ).Interface().(transport.Message)
-				sub.status <- transport.ReceivedMessage{
-					Message: message,
-					Error:   message.Unmarshall(msg),
-				}
+			case sub.status <- transport.ReceivedMessage{
+				Message: message,
+				Error:   message.Unmarshall(msg),
+			}:
 			}
-		}()
-		return sub.status
+		}
 	}
-	return nil
 }
 
 // contextCancelHandler handles context cancellation.
@@ -112,8 +143,11 @@ func (l *Local) contextCancelHandler() {
 	defer func() { close(l.doneCh) }()
 	<-l.ctx.Done()
 
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	l.wg.Wait()
 	for _, sub := range l.subs {
 		close(sub.status)
 	}
-	l.subs = make(map[string]subscription)
 }
@@ -0,0 +1,119 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+	"github.com/makerdao/oracle-suite/pkg/oracle"
+)
+
+var PriceBundleMessageName = "price_bundle/v0"
+
+var ErrPriceBundleMalformedMessage = errors.New("malformed price bundle message")
+var ErrPriceBundleEmpty = errors.New("price bundle must contain at least one price")
+
+// PriceBundle contains multiple signed prices sent by a single feeder.
+// In addition to signatures of individual prices, the bundle is signed
+// with a single envelope signature.
+type PriceBundle struct {
+	Prices    []*oracle.Price
+	Signature ethereum.Signature
+}
+
+type jsonPriceBundle struct {
+	Prices    []*oracle.Price `json:"prices"`
+	Signature string          `json:"signature"`
+}
+
+// Sign adds the envelope signature to the bundle. Prices must already be
+// signed.
+func (p *PriceBundle) Sign(signer ethereum.Signer) error {
+	if len(p.Prices) == 0 {
+		return ErrPriceBundleEmpty
+	}
+	signature, err := signer.Signature(p.hash())
+	if err != nil {
+		return err
+	}
+	p.Signature = signature
+	return nil
+}
+
+// From returns the address of the feeder who signed the bundle.
+func (p *PriceBundle) From(signer ethereum.Signer) (*ethereum.Address, error) {
+	return signer.Recover(p.Signature, p.hash())
+}
+
+// Messages unpacks the bundle into separate price messages.
+func (p *PriceBundle) Messages() []*Price {
+	var msgs []*Price
+	for _, price := range p.Prices {
+		msgs = append(msgs, &Price{Price: price})
+	}
+	return msgs
+}
+
+func (p *PriceBundle) Marshall() ([]byte, error) {
+	return json.Marshal(jsonPriceBundle{
+		Prices:    p.Prices,
+		Signature: hex.EncodeToString(p.Signature.Bytes()),
+	})
+}
+
+func (p *PriceBundle) Unmarshall(b []byte) error {
+	j := &jsonPriceBundle{}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	if len(j.Prices) == 0 {
+		return ErrPriceBundleMalformedMessage
+	}
+	for _, price := range j.Prices {
+		if price == nil || price.Val == nil {
+			return ErrPriceBundleMalformedMessage
+		}
+	}
+	sig, err := hex.DecodeString(j.Signature)
+	if err != nil || len(sig) != ethereum.SignatureLength {
+		return ErrPriceBundleMalformedMessage
+	}
+	p.Prices = j.Prices
+	p.Signature = ethereum.SignatureFromBytes(sig)
+	return nil
+}
+
+func (p *PriceBundle) MarshalBinary() ([]byte, error) {
+	return p.Marshall()
+}
+
+func (p *PriceBundle) UnmarshalBinary(data []byte) error {
+	return p.Unmarshall(data)
+}
+
+// hash returns the hash of signatures of all prices in the bundle. Because
+// every price signature covers the price data, the envelope signature
+// covers all prices in the bundle.
+func (p *PriceBundle) hash() []byte {
+	var b []byte
+	for _, price := range p.Prices {
+		b = append(b, price.Signature().Bytes()...)
+	}
+	return ethereum.SHA3Hash(b)
+}
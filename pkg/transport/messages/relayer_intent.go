@@ -0,0 +1,101 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/makerdao/oracle-suite/pkg/ethereum"
+)
+
+var RelayerIntentMessageName = "relayer_intent/v0"
+
+var ErrRelayerIntentMalformedMessage = errors.New("malformed relayer intent message")
+
+// RelayerIntent is broadcast by a relayer right before it sends a poke
+// transaction for the given asset pair, so other relayers can refrain from
+// sending duplicate transactions.
+type RelayerIntent struct {
+	AssetPair string
+	Timestamp time.Time
+	Signature ethereum.Signature
+}
+
+type jsonRelayerIntent struct {
+	AssetPair string `json:"wat"`
+	Timestamp int64  `json:"timestamp"`
+	Signature string `json:"signature"`
+}
+
+// Sign signs the intent using the given signer.
+func (r *RelayerIntent) Sign(signer ethereum.Signer) error {
+	signature, err := signer.Signature(r.hash())
+	if err != nil {
+		return err
+	}
+	r.Signature = signature
+	return nil
+}
+
+// From returns the address of the relayer who signed the intent.
+func (r *RelayerIntent) From(signer ethereum.Signer) (*ethereum.Address, error) {
+	return signer.Recover(r.Signature, r.hash())
+}
+
+func (r *RelayerIntent) Marshall() ([]byte, error) {
+	return json.Marshal(jsonRelayerIntent{
+		AssetPair: r.AssetPair,
+		Timestamp: r.Timestamp.Unix(),
+		Signature: hex.EncodeToString(r.Signature.Bytes()),
+	})
+}
+
+func (r *RelayerIntent) Unmarshall(b []byte) error {
+	j := &jsonRelayerIntent{}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	if j.AssetPair == "" {
+		return ErrRelayerIntentMalformedMessage
+	}
+	sig, err := hex.DecodeString(j.Signature)
+	if err != nil || len(sig) != ethereum.SignatureLength {
+		return ErrRelayerIntentMalformedMessage
+	}
+	r.AssetPair = j.AssetPair
+	r.Timestamp = time.Unix(j.Timestamp, 0)
+	r.Signature = ethereum.SignatureFromBytes(sig)
+	return nil
+}
+
+func (r *RelayerIntent) MarshalBinary() ([]byte, error) {
+	return r.Marshall()
+}
+
+func (r *RelayerIntent) UnmarshalBinary(data []byte) error {
+	return r.Unmarshall(data)
+}
+
+// hash returns the hash of the asset pair name and the timestamp.
+func (r *RelayerIntent) hash() []byte {
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(r.Timestamp.Unix()))
+	return ethereum.SHA3Hash(append([]byte(r.AssetPair), ts...))
+}
@@ -31,10 +31,19 @@ import (
 
 // oracle adds a validator for price messages. The validator checks if the
 // author of the message is allowed to send price messages, the price
-// message is valid, and if the price is not older than 5 min.
-func oracle(feeders []ethereum.Address, signer ethereum.Signer, logger log.Logger) p2p.Options {
+// message is valid, and if the price is not older than 5 min. Price bundles
+// are validated in the same way, every price in the bundle must be signed
+// by the author of the bundle. Relayer intents are accepted only if they are
+// signed by the author of the message who is one of the relayers.
+func oracle(feeders, relayers []ethereum.Address, signer ethereum.Signer, logger log.Logger) p2p.Options {
 	return func(n *p2p.Node) error {
 		n.AddValidator(func(ctx context.Context, topic string, id peer.ID, psMsg *pubsub.Message) pubsub.ValidationResult {
+			if bundleMsg, ok := psMsg.ValidatorData.(*messages.PriceBundle); ok {
+				return validatePriceBundle(bundleMsg, psMsg, feeders, signer, logger)
+			}
+			if intentMsg, ok := psMsg.ValidatorData.(*messages.RelayerIntent); ok {
+				return validateRelayerIntent(intentMsg, psMsg, relayers, signer, logger)
+			}
 			priceMsg, ok := psMsg.ValidatorData.(*messages.Price)
 			if !ok {
 				return pubsub.ValidationAccept
@@ -103,3 +112,140 @@ func oracle(feeders []ethereum.Address, signer ethereum.Signer, logger log.Logge
 		return nil
 	}
 }
+
+func validatePriceBundle(
+	bundleMsg *messages.PriceBundle,
+	psMsg *pubsub.Message,
+	feeders []ethereum.Address,
+	signer ethereum.Signer,
+	logger log.Logger,
+) pubsub.ValidationResult {
+
+	// Check if the envelope signature is valid and extract author's address:
+	bundleFrom, err := bundleMsg.From(signer)
+	if err != nil {
+		logger.
+			WithError(err).
+			WithField("peerID", psMsg.GetFrom().String()).
+			Warn("The price bundle message was rejected, invalid signature")
+		return pubsub.ValidationReject
+	}
+	// The libp2p message should be created by the same person who signs the bundle:
+	if ethkey.AddressToPeerID(*bundleFrom) != psMsg.GetFrom() {
+		logger.
+			WithField("peerID", psMsg.GetFrom().String()).
+			WithField("from", bundleFrom.String()).
+			Warn("The price bundle message was rejected, the message author and bundle signature don't match")
+		return pubsub.ValidationReject
+	}
+	// Check if an author is allowed to send price messages:
+	feedAllowed := false
+	for _, addr := range feeders {
+		if addr == *bundleFrom {
+			feedAllowed = true
+			break
+		}
+	}
+	if !feedAllowed {
+		logger.
+			WithField("peerID", psMsg.GetFrom().String()).
+			WithField("from", bundleFrom.String()).
+			Warn("The price bundle message was ignored, the feeder is not allowed to send price messages")
+		return pubsub.ValidationIgnore
+	}
+	result := pubsub.ValidationAccept
+	for _, price := range bundleMsg.Prices {
+		wat := price.Wat
+		age := price.Age.UTC().Format(time.RFC3339)
+		val := price.Val.String()
+		// Every price must be signed by the author of the bundle:
+		priceFrom, err := price.From(signer)
+		if err != nil || *priceFrom != *bundleFrom {
+			logger.
+				WithField("peerID", psMsg.GetFrom().String()).
+				WithField("from", bundleFrom.String()).
+				WithField("wat", wat).
+				WithField("age", age).
+				WithField("val", val).
+				Warn("The price bundle message was rejected, the price signature doesn't match the bundle signature")
+			return pubsub.ValidationReject
+		}
+		// Check when price was created, ignore if older than 5 min, reject if older than 10 min:
+		if time.Since(price.Age) > 5*time.Minute {
+			logger.
+				WithField("peerID", psMsg.GetFrom().String()).
+				WithField("from", bundleFrom.String()).
+				WithField("wat", wat).
+				WithField("age", age).
+				WithField("val", val).
+				Warn("The price bundle message was rejected, the price is older than 5 min")
+			if time.Since(price.Age) > 10*time.Minute {
+				return pubsub.ValidationReject
+			}
+			result = pubsub.ValidationIgnore
+		}
+	}
+
+	return result
+}
+
+func validateRelayerIntent(
+	intentMsg *messages.RelayerIntent,
+	psMsg *pubsub.Message,
+	relayers []ethereum.Address,
+	signer ethereum.Signer,
+	logger log.Logger,
+) pubsub.ValidationResult {
+
+	// Check if the signature is valid and extract author's address:
+	intentFrom, err := intentMsg.From(signer)
+	if err != nil {
+		logger.
+			WithError(err).
+			WithField("peerID", psMsg.GetFrom().String()).
+			WithField("wat", intentMsg.AssetPair).
+			Warn("The relayer intent message was rejected, invalid signature")
+		return pubsub.ValidationReject
+	}
+	// The libp2p message should be created by the same person who signs the intent:
+	if ethkey.AddressToPeerID(*intentFrom) != psMsg.GetFrom() {
+		logger.
+			WithField("peerID", psMsg.GetFrom().String()).
+			WithField("from", intentFrom.String()).
+			WithField("wat", intentMsg.AssetPair).
+			Warn("The relayer intent message was rejected, the message author and intent signature don't match")
+		return pubsub.ValidationReject
+	}
+	// Check if an author is allowed to send relayer intents:
+	relayerAllowed := false
+	for _, addr := range relayers {
+		if addr == *intentFrom {
+			relayerAllowed = true
+			break
+		}
+	}
+	if !relayerAllowed {
+		logger.
+			WithField("peerID", psMsg.GetFrom().String()).
+			WithField("from", intentFrom.String()).
+			WithField("wat", intentMsg.AssetPair).
+			Warn("The relayer intent message was ignored, the author is not in the relayer set")
+		return pubsub.ValidationIgnore
+	}
+	// Intents are only relevant for a short time, ignore if older than 5 min:
+	if time.Since(intentMsg.Timestamp) > 5*time.Minute {
+		return pubsub.ValidationIgnore
+	}
+	// Intents from the future would block other relayers for too long, reject
+	// if more than 10 sec ahead:
+	if time.Until(intentMsg.Timestamp) > 10*time.Second {
+		logger.
+			WithField("peerID", psMsg.GetFrom().String()).
+			WithField("from", intentFrom.String()).
+			WithField("wat", intentMsg.AssetPair).
+			Warn("The relayer intent message was rejected, the timestamp is in the future")
+		return pubsub.ValidationReject
+	}
+
+	return pubsub.ValidationAccept
+}
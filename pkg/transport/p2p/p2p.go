@@ -99,6 +99,9 @@ type Config struct {
 	// FeedersAddrs is a list of price feeders. Only feeders can create new
 	// messages in the network.
 	FeedersAddrs []ethereum.Address
+	// RelayersAddrs is a list of relayers. Only relayers can send relayer
+	// intents.
+	RelayersAddrs []ethereum.Address
 	// Discovery indicates whenever peer discovery should be enabled.
 	// If discovery is disabled, then DirectPeersAddrs must be used
 	// to connect to the network. Always enabled in bootstrap mode.
@@ -157,6 +160,7 @@ func New(ctx context.Context, cfg Config) (*P2P, error) {
 			5*time.Minute,
 		),
 		p2p.Monitor(),
+		p2p.Metrics(),
 	}
 	if cfg.PeerPrivKey != nil {
 		opts = append(opts, p2p.PeerPrivKey(cfg.PeerPrivKey))
@@ -167,12 +171,12 @@ func New(ctx context.Context, cfg Config) (*P2P, error) {
 			p2p.MessageLogger(),
 			p2p.RateLimiter(rateLimiterConfig(cfg)),
 			p2p.PeerScoring(peerScoreParams, thresholds, func(topic string) *pubsub.TopicScoreParams {
-				if topic == messages.PriceMessageName {
+				if topic == messages.PriceMessageName || topic == messages.PriceBundleMessageName {
 					return priceTopicScoreParams(cfg)
 				}
 				return nil
 			}),
-			oracle(cfg.FeedersAddrs, cfg.Signer, logger),
+			oracle(cfg.FeedersAddrs, cfg.RelayersAddrs, cfg.Signer, logger),
 		)
 		if cfg.MessagePrivKey != nil {
 			opts = append(opts, p2p.MessagePrivKey(cfg.MessagePrivKey))
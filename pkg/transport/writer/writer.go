@@ -0,0 +1,99 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package writer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/makerdao/oracle-suite/pkg/transport"
+)
+
+// Writer is an implementation of the transport.Transport interface which
+// writes broadcast messages to an io.Writer instead of sending them to the
+// network. Every message is written in a separate line, so the output
+// uses the NDJSON format if messages are marshalled to JSON.
+//
+// The Writer does not receive any messages, so the Messages method always
+// returns nil.
+type Writer struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	doneCh chan struct{}
+	w      io.Writer
+}
+
+// New returns a new instance of the Writer structure.
+func New(ctx context.Context, w io.Writer) (*Writer, error) {
+	if ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	return &Writer{
+		ctx:    ctx,
+		doneCh: make(chan struct{}),
+		w:      w,
+	}, nil
+}
+
+// Start implements the transport.Transport interface.
+func (w *Writer) Start() error {
+	go w.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the transport.Transport interface.
+func (w *Writer) Wait() {
+	<-w.doneCh
+}
+
+// Broadcast implements the transport.Transport interface.
+func (w *Writer) Broadcast(_ string, message transport.Message) error {
+	b, err := message.Marshall()
+	if err != nil {
+		return err
+	}
+
+	// Messages must not contain new lines:
+	buf := &bytes.Buffer{}
+	if json.Valid(b) {
+		if err := json.Compact(buf, b); err != nil {
+			return err
+		}
+	} else {
+		buf.Write(bytes.ReplaceAll(b, []byte("\n"), []byte(" ")))
+	}
+	buf.WriteByte('\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.w.Write(buf.Bytes())
+	return err
+}
+
+// Messages implements the transport.Transport interface.
+func (w *Writer) Messages(_ string) chan transport.ReceivedMessage {
+	return nil
+}
+
+// contextCancelHandler handles context cancellation.
+func (w *Writer) contextCancelHandler() {
+	defer func() { close(w.doneCh) }()
+	<-w.ctx.Done()
+}
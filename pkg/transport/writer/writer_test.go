@@ -0,0 +1,55 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package writer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMsg struct {
+	Val string
+}
+
+func (t *testMsg) Marshall() ([]byte, error) {
+	return []byte(t.Val), nil
+}
+
+func (t *testMsg) Unmarshall(b []byte) error {
+	t.Val = string(b)
+	return nil
+}
+
+func TestWriter_Broadcast(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	buf := &bytes.Buffer{}
+	w, err := New(ctx, buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Start())
+
+	assert.NoError(t, w.Broadcast("foo", &testMsg{Val: "{\n  \"a\": 1\n}"}))
+	assert.NoError(t, w.Broadcast("foo", &testMsg{Val: "b\nc"}))
+	assert.Nil(t, w.Messages("foo"))
+	assert.Equal(t, "{\"a\":1}\nb c\n", buf.String())
+
+	ctxCancel()
+	w.Wait()
+}